@@ -0,0 +1,284 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// -----------------------------
+// Calendar：绑定时区的日历
+// -----------------------------
+
+// ErrInvalidCalendar 日历配置无效。
+var ErrInvalidCalendar = errors.New("invalid calendar")
+
+// Calendar 绑定某个时区的日历：自然日 / 周 / 月的起止、工作日推算（周末、节假日与调休上班日）、ISO 周，
+// 以及毫秒时间戳的换算。所有计算只使用自身的 Location，不读取也不修改 time.Local，
+// 多租户服务可以为每个租户各建一个。创建后不可变，可并发使用。
+//
+//	cal, _ := dt.NewCalendar(shanghai,
+//		dt.WithHolidays("2025-10-01", "2025-10-02", ...),
+//		dt.WithWorkdays("2025-09-28", "2025-10-11"), // 调休上班
+//	)
+//	due := cal.AddBusinessDays(cal.Now(), 3)
+type Calendar struct {
+	loc       *time.Location
+	weekStart time.Weekday
+	weekend   [7]bool
+	holidays  map[int]bool // 平日放假
+	workdays  map[int]bool // 周末调休上班
+}
+
+// CalendarOption 创建 Calendar 时的可选配置。
+type CalendarOption func(*Calendar) error
+
+// WithWeekStart 一周的第一天，默认周一。
+func WithWeekStart(day time.Weekday) CalendarOption {
+	return func(c *Calendar) error {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: week start %d", ErrInvalidCalendar, day)
+		}
+		c.weekStart = day
+		return nil
+	}
+}
+
+// WithWeekend 周末的日子，默认周六、周日；如中东地区为周五、周六。
+func WithWeekend(days ...time.Weekday) CalendarOption {
+	return func(c *Calendar) error {
+		c.weekend = [7]bool{}
+		for _, d := range days {
+			if d < time.Sunday || d > time.Saturday {
+				return fmt.Errorf("%w: weekend day %d", ErrInvalidCalendar, d)
+			}
+			c.weekend[d] = true
+		}
+		return nil
+	}
+}
+
+// WithHolidays 放假的日期，格式 "2006-01-02"。
+func WithHolidays(dates ...string) CalendarOption {
+	return func(c *Calendar) error {
+		return addDates(c.holidays, dates)
+	}
+}
+
+// WithWorkdays 调休需要上班的日期（通常是周末），格式 "2006-01-02"。
+func WithWorkdays(dates ...string) CalendarOption {
+	return func(c *Calendar) error {
+		return addDates(c.workdays, dates)
+	}
+}
+
+func addDates(set map[int]bool, dates []string) error {
+	for _, s := range dates {
+		t, err := time.Parse(time.DateOnly, strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("%w: date %q", ErrInvalidCalendar, s)
+		}
+		set[dateKey(t)] = true
+	}
+	return nil
+}
+
+// NewCalendar 创建日历，loc 为 nil 时使用 UTC。
+func NewCalendar(loc *time.Location, opts ...CalendarOption) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	c := &Calendar{
+		loc:       loc,
+		weekStart: time.Monday,
+		holidays:  map[int]bool{},
+		workdays:  map[int]bool{},
+	}
+	c.weekend[time.Saturday] = true
+	c.weekend[time.Sunday] = true
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.weekend == [7]bool{true, true, true, true, true, true, true} {
+		return nil, fmt.Errorf("%w: every day is a weekend", ErrInvalidCalendar)
+	}
+	return c, nil
+}
+
+// LoadCalendar 按时区名称（如 "Asia/Shanghai"）创建日历。
+func LoadCalendar(name string, opts ...CalendarOption) (*Calendar, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCalendar, err)
+	}
+	return NewCalendar(loc, opts...)
+}
+
+// dateKey 日期的整数键 yyyymmdd，取 t 自身时区下的年月日。
+func dateKey(t time.Time) int {
+	y, m, d := t.Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// Location 日历的时区。
+func (c *Calendar) Location() *time.Location { return c.loc }
+
+// Now 日历时区下的当前时间。
+func (c *Calendar) Now() time.Time { return time.Now().In(c.loc) }
+
+// In 把 t 转到日历时区。
+func (c *Calendar) In(t time.Time) time.Time { return t.In(c.loc) }
+
+// Date 日历时区下某天的零点。
+func (c *Calendar) Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, c.loc)
+}
+
+// Parse 按 layout 解析，字符串不带时区时按日历时区解释。
+func (c *Calendar) Parse(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, c.loc)
+}
+
+// -----------------------------
+// 毫秒时间戳
+// -----------------------------
+
+// FromMillis 毫秒时间戳转为日历时区下的时间。
+func (c *Calendar) FromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).In(c.loc)
+}
+
+// ParseMillis 字符串形式的毫秒时间戳转为日历时区下的时间。
+func (c *Calendar) ParseMillis(ms string) (time.Time, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(ms), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid millisecond timestamp: %w", err)
+	}
+	return c.FromMillis(v), nil
+}
+
+// FormatMillis 按日历时区格式化毫秒时间戳，layout 为空时使用 "2006-01-02 15:04:05"。
+func (c *Calendar) FormatMillis(ms int64, layoutStr string) string {
+	if layoutStr == "" {
+		layoutStr = layout
+	}
+	return c.FromMillis(ms).Format(layoutStr)
+}
+
+// -----------------------------
+// 自然日 / 周 / 月
+// -----------------------------
+
+// StartOfDay 所在日的零点。
+func (c *Calendar) StartOfDay(t time.Time) time.Time {
+	y, m, d := t.In(c.loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, c.loc)
+}
+
+// EndOfDay 所在日的最后一纳秒，即次日零点前 1ns；区间查询建议用 [StartOfDay, 次日零点)。
+func (c *Calendar) EndOfDay(t time.Time) time.Time {
+	return c.StartOfDay(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// StartOfWeek 所在周第一天（WithWeekStart，默认周一）的零点。
+func (c *Calendar) StartOfWeek(t time.Time) time.Time {
+	day := c.StartOfDay(t)
+	offset := (int(day.Weekday()) - int(c.weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// EndOfWeek 所在周的最后一纳秒。
+func (c *Calendar) EndOfWeek(t time.Time) time.Time {
+	return c.StartOfWeek(t).AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// StartOfMonth 所在月 1 日的零点。
+func (c *Calendar) StartOfMonth(t time.Time) time.Time {
+	y, m, _ := t.In(c.loc).Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, c.loc)
+}
+
+// EndOfMonth 所在月的最后一纳秒。
+func (c *Calendar) EndOfMonth(t time.Time) time.Time {
+	return c.StartOfMonth(t).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// ISOWeek 日历时区下的 ISO 8601 年份与周数（周一开始，与 WithWeekStart 无关）。
+func (c *Calendar) ISOWeek(t time.Time) (year, week int) {
+	return t.In(c.loc).ISOWeek()
+}
+
+// ParseRange 以日历时区和当前时间解析时间范围，语法见 ParseRange；this_week、last_week 与 "/w" 按 WithWeekStart 划分周。
+func (c *Calendar) ParseRange(expr string) (from, to time.Time, err error) {
+	return parseRange(expr, c.Now(), c.weekStart)
+}
+
+// -----------------------------
+// 工作日
+// -----------------------------
+
+// IsWeekend 是否为周末（不考虑调休）。
+func (c *Calendar) IsWeekend(t time.Time) bool {
+	return c.weekend[t.In(c.loc).Weekday()]
+}
+
+// IsHoliday 是否为登记的节假日。
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	return c.holidays[dateKey(t.In(c.loc))]
+}
+
+// IsBusinessDay 是否为工作日：调休上班日一定是工作日，否则排除周末与节假日。
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	local := t.In(c.loc)
+	key := dateKey(local)
+	if c.workdays[key] {
+		return true
+	}
+	return !c.weekend[local.Weekday()] && !c.holidays[key]
+}
+
+// AddBusinessDays 向后（n<0 时向前）推 n 个工作日，保留时分秒；n 为 0 时原样返回。
+// 如周五加 1 个工作日为下周一。
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	local := t.In(c.loc)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		local = local.AddDate(0, 0, step)
+		if c.IsBusinessDay(local) {
+			n--
+		}
+	}
+	return local
+}
+
+// NextBusinessDay t 当天是工作日则返回 t，否则返回之后第一个工作日的同一时刻。
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	local := t.In(c.loc)
+	for !c.IsBusinessDay(local) {
+		local = local.AddDate(0, 0, 1)
+	}
+	return local
+}
+
+// BusinessDaysBetween [from, to) 之间按日期计算的工作日数，to 早于 from 时返回负数。
+func (c *Calendar) BusinessDaysBetween(from, to time.Time) int {
+	start, end := c.StartOfDay(from), c.StartOfDay(to)
+	sign := 1
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+	count := 0
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			count++
+		}
+	}
+	return sign * count
+}
@@ -122,6 +122,7 @@ func ConvertStrMillisToTime(millis string) (time.Time, error) {
 }
 
 // SetTimezone 设置时区，默认上海
+// 会修改全局的 time.Local，影响进程内所有代码；按租户区分时区时请使用 Calendar。
 func SetTimezone(tz ...string) {
 	defaultTimezone := "Asia/Shanghai"
 
@@ -0,0 +1,380 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// -----------------------------
+// 时长：1d2h、2w、3mo
+// -----------------------------
+
+// ErrInvalidTimeRange 无法识别的时间范围表达式。
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// Span 区分日历单位与固定时长的时间跨度：年、月、日按日历推算（跨夏令时、月末仍然正确），
+// 时、分、秒等固定部分存于 Duration。
+type Span struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// ParseSpan 解析时长，格式为若干个 "整数+单位" 连写，可带前导正负号：
+//
+//	y 年、q 季度、mo / M 月、w 周、d 天、h 时、m 分、s 秒、ms 毫秒
+//
+// 如 "1d2h"、"2w"、"-3mo"、"1y6mo"。注意这里 m 为分钟，与 AdjustMilliTimestampByStr 不同。
+// 其余 time.ParseDuration 能识别的写法（"1.5h"、"300us"）也可使用。
+func ParseSpan(s string) (Span, error) {
+	text := strings.TrimSpace(s)
+	sign := 1
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		if text[0] == '-' {
+			sign = -1
+		}
+		text = text[1:]
+	}
+	if text == "" {
+		return Span{}, fmt.Errorf("%w: %q", ErrInvalidDurationFormat, s)
+	}
+
+	sp, err := parseSpanUnits(text)
+	if err != nil {
+		d, derr := time.ParseDuration(text)
+		if derr != nil {
+			return Span{}, fmt.Errorf("%w: %q", err, s)
+		}
+		sp = Span{Duration: d}
+	}
+	if sign < 0 {
+		sp = sp.Neg()
+	}
+	return sp, nil
+}
+
+func parseSpanUnits(text string) (Span, error) {
+	var sp Span
+	for text != "" {
+		i := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return Span{}, ErrInvalidDurationFormat
+		}
+		n, err := strconv.Atoi(text[:i])
+		if err != nil {
+			return Span{}, ErrInvalidNumber
+		}
+		rest := text[i:]
+		j := strings.IndexFunc(rest, func(r rune) bool { return r >= '0' && r <= '9' })
+		if j < 0 {
+			j = len(rest)
+		}
+		ok := true
+		switch rest[:j] {
+		case "y":
+			sp.Years, ok = addScaled(sp.Years, n, 1)
+		case "q":
+			sp.Months, ok = addScaled(sp.Months, n, 3)
+		case "mo", "M":
+			sp.Months, ok = addScaled(sp.Months, n, 1)
+		case "w":
+			sp.Days, ok = addScaled(sp.Days, n, 7)
+		case "d":
+			sp.Days, ok = addScaled(sp.Days, n, 1)
+		case "h":
+			sp.Duration, ok = addDuration(sp.Duration, n, time.Hour)
+		case "m":
+			sp.Duration, ok = addDuration(sp.Duration, n, time.Minute)
+		case "s":
+			sp.Duration, ok = addDuration(sp.Duration, n, time.Second)
+		case "ms":
+			sp.Duration, ok = addDuration(sp.Duration, n, time.Millisecond)
+		default:
+			return Span{}, ErrInvalidTimeUnit
+		}
+		if !ok {
+			return Span{}, ErrInvalidNumber
+		}
+		text = rest[j:]
+	}
+	return sp, nil
+}
+
+// addScaled 返回 sum + n*scale，溢出时返回 false；n、scale 非负。
+func addScaled(sum, n, scale int) (int, bool) {
+	if n > (math.MaxInt-sum)/scale {
+		return sum, false
+	}
+	return sum + n*scale, true
+}
+
+// addDuration 返回 sum + n*unit，超出 time.Duration 范围时返回 false；n 非负。
+func addDuration(sum time.Duration, n int, unit time.Duration) (time.Duration, bool) {
+	if int64(n) > int64(math.MaxInt64-sum)/int64(unit) {
+		return sum, false
+	}
+	return sum + time.Duration(n)*unit, true
+}
+
+// Neg 反向的跨度。
+func (s Span) Neg() Span {
+	return Span{Years: -s.Years, Months: -s.Months, Days: -s.Days, Duration: -s.Duration}
+}
+
+// IsZero 是否为空跨度。
+func (s Span) IsZero() bool {
+	return s == Span{}
+}
+
+// AddTo 将跨度加到 t 上：先按年月推算（目标月没有该日时取月末，如 1 月 31 日加 1 个月为 2 月 28/29 日），
+// 再加天数，最后加固定时长。
+func (s Span) AddTo(t time.Time) time.Time {
+	if s.Years != 0 || s.Months != 0 {
+		t = addMonths(t, s.Years*12+s.Months)
+	}
+	if s.Days != 0 {
+		t = t.AddDate(0, 0, s.Days)
+	}
+	return t.Add(s.Duration)
+}
+
+// addMonths 加月份并把日期限制在目标月的天数内，避免 time.AddDate 的溢出进位（1-31 加一月得 3 月）。
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := daysIn(first.Year(), first.Month()); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// -----------------------------
+// 取整：/d、/w、/mo
+// -----------------------------
+
+// StartOf 返回 t 在其时区内所属单位的起点，unit 为 y、q、mo（M）、w（周一为一周第一天）、d、h、m、s。
+func StartOf(t time.Time, unit string) (time.Time, error) {
+	return startOf(t, unit, time.Monday)
+}
+
+// startOf 同 StartOf，一周从 weekStart 开始。
+func startOf(t time.Time, unit string, weekStart time.Weekday) (time.Time, error) {
+	y, mo, d := t.Date()
+	loc := t.Location()
+	switch unit {
+	case "y":
+		return time.Date(y, 1, 1, 0, 0, 0, 0, loc), nil
+	case "q":
+		return time.Date(y, (mo-1)/3*3+1, 1, 0, 0, 0, 0, loc), nil
+	case "mo", "M":
+		return time.Date(y, mo, 1, 0, 0, 0, 0, loc), nil
+	case "w":
+		offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(y, mo, d-offset, 0, 0, 0, 0, loc), nil
+	case "d":
+		return time.Date(y, mo, d, 0, 0, 0, 0, loc), nil
+	case "h":
+		return time.Date(y, mo, d, t.Hour(), 0, 0, 0, loc), nil
+	case "m":
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), 0, 0, loc), nil
+	case "s":
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeUnit, unit)
+}
+
+// periodSpan 与 StartOf 单位对应的一个周期长度，用于求下一个周期的起点。
+func periodSpan(unit string) Span {
+	switch unit {
+	case "y":
+		return Span{Years: 1}
+	case "q":
+		return Span{Months: 3}
+	case "mo", "M":
+		return Span{Months: 1}
+	case "w":
+		return Span{Days: 7}
+	case "d":
+		return Span{Days: 1}
+	case "h":
+		return Span{Duration: time.Hour}
+	case "m":
+		return Span{Duration: time.Minute}
+	default:
+		return Span{Duration: time.Second}
+	}
+}
+
+// -----------------------------
+// 相对时间与时间范围
+// -----------------------------
+
+// ParseRelative 解析相对时间表达式，基准为 now 在 loc 时区（nil 时沿用 now 的时区）下的时间：
+//
+//	now、now-7d、now-7d/d（7 天前的零点）、now/w（本周一零点）、now-1mo/mo+1d
+//
+// 加减与取整从左到右依次应用。基准也可以是绝对时间（RFC3339、"2006-01-02 15:04:05" 或 "2006-01-02"，
+// 无时区的按 loc 解释），如 "2024-03-01/mo"、"2024-03-01-7d"；"today" 等同于 "now/d"。
+func ParseRelative(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	return parseRelative(expr, now, time.Monday)
+}
+
+// parseRelative 同 ParseRelative，"/w" 取整到 weekStart。
+func parseRelative(expr string, now time.Time, weekStart time.Weekday) (time.Time, error) {
+	text := strings.TrimSpace(expr)
+	t, rest, err := parseAnchor(text, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for rest != "" {
+		op := rest[0]
+		if op != '+' && op != '-' && op != '/' {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDurationFormat, expr)
+		}
+		end := strings.IndexAny(rest[1:], "+-/")
+		if end < 0 {
+			end = len(rest)
+		} else {
+			end++
+		}
+		operand := rest[1:end]
+		rest = rest[end:]
+
+		if op == '/' {
+			if t, err = startOf(t, operand, weekStart); err != nil {
+				return time.Time{}, err
+			}
+			continue
+		}
+		sp, err := ParseSpan(operand)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if op == '-' {
+			sp = sp.Neg()
+		}
+		t = sp.AddTo(t)
+	}
+	return t, nil
+}
+
+// parseAnchor 解析表达式开头的基准时间，返回剩余的运算部分。
+func parseAnchor(text string, now time.Time) (time.Time, string, error) {
+	switch {
+	case strings.HasPrefix(text, "now"):
+		return now, text[3:], nil
+	case strings.HasPrefix(text, "today"):
+		t, _ := StartOf(now, "d")
+		return t, text[5:], nil
+	}
+
+	// 绝对时间：日期本身含有 '-'，从后往前找能解析为时间的最长前缀，其后为运算部分
+	for i := len(text); i > 0; i-- {
+		if i < len(text) && !strings.ContainsRune("+-/", rune(text[i])) {
+			continue
+		}
+		if t, ok := parseAbsolute(text[:i], now.Location()); ok {
+			return t, text[i:], nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("%w: %q", ErrInvalidDurationFormat, text)
+}
+
+func parseAbsolute(s string, loc *time.Location) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), true
+	}
+	for _, l := range []string{layout, time.DateOnly} {
+		if t, err := time.ParseInLocation(l, s, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseRange 解析时间范围，返回左闭右开区间 [from, to)，以 loc 时区（nil 时沿用 now 的时区）划分自然日、周、月：
+//
+//	today、yesterday、this_week、last_week、this_month、last_month、
+//	this_quarter、last_quarter、this_year、last_year
+//	last_7d、last_24h、last_3mo   截至 now 的滚动区间
+//	now-7d/d..now/d               两个 ParseRelative 表达式以 ".." 连接
+//
+// 周从周一开始。
+func ParseRange(expr string, now time.Time, loc *time.Location) (from, to time.Time, err error) {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	return parseRange(expr, now, time.Monday)
+}
+
+// parseRange 同 ParseRange，周从 weekStart 开始。
+func parseRange(expr string, now time.Time, weekStart time.Weekday) (from, to time.Time, err error) {
+	text := strings.TrimSpace(expr)
+
+	if a, b, ok := strings.Cut(text, ".."); ok {
+		if from, err = parseRelative(a, now, weekStart); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if to, err = parseRelative(b, now, weekStart); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if to.Before(from) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %q ends before it starts", ErrInvalidTimeRange, expr)
+		}
+		return from, to, nil
+	}
+
+	var unit string
+	var back int
+	switch text {
+	case "today":
+		unit = "d"
+	case "yesterday":
+		unit, back = "d", 1
+	default:
+		which, period, ok := strings.Cut(text, "_")
+		if !ok {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, expr)
+		}
+		if which == "last" {
+			if sp, err := ParseSpan(period); err == nil {
+				return sp.Neg().AddTo(now), now, nil
+			}
+			back = 1
+		} else if which != "this" {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, expr)
+		}
+		if unit = rangeUnits[period]; unit == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, expr)
+		}
+	}
+
+	start, _ := startOf(now, unit, weekStart)
+	period := periodSpan(unit)
+	if back > 0 {
+		start = period.Neg().AddTo(start)
+	}
+	return start, period.AddTo(start), nil
+}
+
+// rangeUnits this_ / last_ 之后的周期名称。
+var rangeUnits = map[string]string{
+	"day":     "d",
+	"week":    "w",
+	"month":   "mo",
+	"quarter": "q",
+	"year":    "y",
+}
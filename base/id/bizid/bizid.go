@@ -17,6 +17,14 @@
 //	// 业务 ID
 //	orderNo := bizid.GetRechargeOrderID()  // 例：RGFQRJVPK543304808
 //
+//	// 自定义格式：Crockford base32 + 校验字符 + 分组，便于电话报单
+//	bizid.MustRegister(bizid.KindWithdraw, bizid.Format{
+//		Prefix: "WD", Encoding: bizid.EncodingCrockford,
+//		Check: bizid.CheckISO7064, GroupSize: 4, Separator: "-",
+//	})
+//	no := bizid.MustGenerate(bizid.KindWithdraw)
+//	kind, err := bizid.Validate(input) // 客服录入：反查种类 + 校验
+//
 //	// 原始雪花 ID（int64，可直接做 PG 主键 / 业务整数 ID）
 //	id := bizid.MustGetSnowflakeID()
 package bizid
@@ -58,6 +66,19 @@ func Init(workerId int64) error {
 	return nil
 }
 
+// InitFast 同 Init，但使用无锁的高吞吐生成器（见 snowflake.NewFastShortIdGenerator）。
+// 适合批量出款这类短时间大量取号的进程；maxDrift 建议 ≤ 1s，且必须小于进程重启耗时。
+func InitFast(workerId int64, maxDrift time.Duration) error {
+	g, err := snowflake.NewFastShortIdGenerator(workerId, maxDrift)
+	if err != nil {
+		return fmt.Errorf("bizid: init snowflake: %w", err)
+	}
+	mu.Lock()
+	gen = g
+	mu.Unlock()
+	return nil
+}
+
 func currentGen() *snowflake.ShortIdGenerator {
 	mu.RLock()
 	defer mu.RUnlock()
@@ -97,13 +118,7 @@ func New(prefix string) string {
 // timeBase26 把 UTC 时间的「年(2)月(2)日(2)时(2)分(2) = 10 位十进制数字」做 base26 编码。
 // 输出长度 ≤ 8 字符。
 func timeBase26(t time.Time) string {
-	yy := uint64(t.Year() % 100)
-	digits := yy*100000000 +
-		uint64(t.Month())*1000000 +
-		uint64(t.Day())*10000 +
-		uint64(t.Hour())*100 +
-		uint64(t.Minute())
-	return encodeBase26(digits)
+	return encodeBase26(timeDigits(t))
 }
 
 // encodeBase26 把无符号整数编码为 A-Z 字母串（A=0, Z=25）。0 -> "A"。
@@ -123,28 +138,28 @@ func encodeBase26(n uint64) string {
 	return string(buf)
 }
 
-// ----- 业务快捷函数（前缀约定） -----
+// ----- 业务快捷函数（走注册表，格式可用 Register 覆盖） -----
 
 // GetRechargeOrderID 充值订单 ID（RG = recharge）
-func GetRechargeOrderID() string { return New("RG") }
+func GetRechargeOrderID() string { return MustGenerate(KindRecharge) }
 
 // GetWithdrawID 提款单 ID（WD = withdraw）
-func GetWithdrawID() string { return New("WD") }
+func GetWithdrawID() string { return MustGenerate(KindWithdraw) }
 
 // GetDepositOrderID 存款订单 ID（DP = deposit）
-func GetDepositOrderID() string { return New("DP") }
+func GetDepositOrderID() string { return MustGenerate(KindDeposit) }
 
 // GetBuyOrderID 购买 / 消费订单 ID（BU = buy）
-func GetBuyOrderID() string { return New("BU") }
+func GetBuyOrderID() string { return MustGenerate(KindBuy) }
 
 // GetExchangeID 兑换订单 ID（EX = exchange）
-func GetExchangeID() string { return New("EX") }
+func GetExchangeID() string { return MustGenerate(KindExchange) }
 
 // GetBonusOrderID 积分订单 ID（BO = bonus order）
-func GetBonusOrderID() string { return New("BO") }
+func GetBonusOrderID() string { return MustGenerate(KindBonus) }
 
 // GetGemOrderID 宝石订单 ID（GM = gem）
-func GetGemOrderID() string { return New("GM") }
+func GetGemOrderID() string { return MustGenerate(KindGem) }
 
 // GetGoldOrderID 金币订单 ID（GD = gold）
-func GetGoldOrderID() string { return New("GD") }
+func GetGoldOrderID() string { return MustGenerate(KindGold) }
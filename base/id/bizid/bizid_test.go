@@ -175,3 +175,18 @@ func TestNewIDsAreDistinctConcurrent(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestInitFastProducesDistinctIDs(t *testing.T) {
+	resetForTest()
+	if err := InitFast(4, 100*time.Millisecond); err != nil {
+		t.Fatalf("InitFast failed: %v", err)
+	}
+	seen := make(map[int64]struct{}, 1000)
+	for i := 0; i < 1000; i++ {
+		id := MustGetSnowflakeID()
+		if _, dup := seen[id]; dup {
+			t.Fatalf("duplicate id %d", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
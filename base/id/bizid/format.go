@@ -0,0 +1,273 @@
+package bizid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encoding 业务 ID 主体（时间段 + 雪花段）的编码方式。
+type Encoding int
+
+const (
+	// EncodingBase26 时间段 base26(A-Z) + 十进制雪花 ID。与 New 的旧格式完全一致。
+	EncodingBase26 Encoding = iota
+	// EncodingCrockford 时间段与雪花段均使用 Crockford base32（0-9A-Z 去掉 I/L/O/U），
+	// 定长：时间段 7 位 + 雪花段 10 位。录入时 I/L 视为 1、O 视为 0、大小写不敏感，适合电话报单。
+	EncodingCrockford
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// 10 位十进制时间数字最大 9_912_312_359 < 32^7
+	crockfordTimeWidth = 7
+	// 雪花总位宽 48 < 5*10
+	crockfordSeqWidth = 10
+)
+
+var (
+	// ErrPrefixMismatch ID 前缀与格式不符。
+	ErrPrefixMismatch = errors.New("bizid: prefix mismatch")
+	// ErrMalformed ID 含非法字符或长度不对。
+	ErrMalformed = errors.New("bizid: malformed id")
+	// ErrChecksum 校验字符不匹配（多半是录入错误）。
+	ErrChecksum = errors.New("bizid: checksum mismatch")
+)
+
+// Format 描述一种业务 ID 的外观：前缀、主体编码、校验字符和分组。
+//
+// 零值（只填 Prefix）等价于 New(prefix) 的旧格式：
+//
+//	bizid.Format{Prefix: "RG"}                             // RGILHABWG543304808
+//	bizid.Format{Prefix: "RG", Check: bizid.CheckISO7064} // RGILHABWG543304808K
+//	bizid.Format{Prefix: "RG", Encoding: bizid.EncodingCrockford,
+//		Check: bizid.CheckISO7064, GroupSize: 4, Separator: "-"} // RG-2DMH-1HP0-000G-64B3-84
+type Format struct {
+	// Prefix 业务前缀，建议 1-3 个大写字母。
+	Prefix string
+	// Encoding 主体编码方式，默认 EncodingBase26。
+	Encoding Encoding
+	// Check 校验字符算法，默认不追加。
+	Check CheckDigit
+	// GroupSize 主体按几个字符一组分隔，<=0 或 Separator 为空时不分组。
+	GroupSize int
+	// Separator 分组分隔符，前缀与主体之间也使用它，如 "-" 或 " "。
+	Separator string
+}
+
+// New 按格式生成一个业务 ID。Init 之前调用返回 ErrNotInitialized。
+func (f Format) New() (string, error) {
+	id, err := GetSnowflakeID()
+	if err != nil {
+		return "", err
+	}
+	return f.build(time.Now().UTC(), id)
+}
+
+// MustNew 同 New，失败时 panic。
+func (f Format) MustNew() string {
+	s, err := f.New()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// build 组装紧凑形式并追加校验字符，最后按 GroupSize 分组。
+func (f Format) build(t time.Time, id int64) (string, error) {
+	var body string
+	switch f.Encoding {
+	case EncodingBase26:
+		body = timeBase26(t) + strconv.FormatInt(id, 10)
+	case EncodingCrockford:
+		body = encodeCrockford(timeDigits(t), crockfordTimeWidth) + encodeCrockford(uint64(id), crockfordSeqWidth)
+	default:
+		return "", fmt.Errorf("bizid: unknown encoding %d", f.Encoding)
+	}
+	if f.Check != CheckNone {
+		c, ok := f.Check.compute(f.Prefix + body)
+		if !ok {
+			return "", fmt.Errorf("bizid: prefix %q not computable by %s check", f.Prefix, f.Check)
+		}
+		body += string(c)
+	}
+	return f.group(body), nil
+}
+
+// group 按 GroupSize 把主体切分并用 Separator 连接，前缀单独成组。
+func (f Format) group(body string) string {
+	if f.GroupSize <= 0 || f.Separator == "" {
+		return f.Prefix + body
+	}
+	var b strings.Builder
+	b.Grow(len(f.Prefix) + len(body) + (len(body)/f.GroupSize+1)*len(f.Separator))
+	b.WriteString(f.Prefix)
+	for i := 0; i < len(body); i += f.GroupSize {
+		if i > 0 || f.Prefix != "" {
+			b.WriteString(f.Separator)
+		}
+		b.WriteString(body[i:min(i+f.GroupSize, len(body))])
+	}
+	return b.String()
+}
+
+// Normalize 把人工录入的 ID 还原为紧凑大写形式：去掉分隔符、空白和 '-'，转大写；
+// Crockford 编码下把主体中的 I/L 视为 1、O 视为 0。不做任何校验。
+func (f Format) Normalize(id string) string {
+	var b strings.Builder
+	b.Grow(len(id))
+	for _, r := range strings.ToUpper(id) {
+		switch r {
+		case ' ', '\t', '-', '_':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	if f.Separator != "" {
+		s = strings.ReplaceAll(s, strings.ToUpper(f.Separator), "")
+	}
+	if f.Encoding != EncodingCrockford || !strings.HasPrefix(s, f.Prefix) {
+		return s
+	}
+
+	// ISO 7064 的校验字符取自 0-9A-Z，可能就是 I/L/O，不能参与 Crockford 纠错
+	end := len(s)
+	if f.Check == CheckISO7064 && end > len(f.Prefix) {
+		end--
+	}
+	buf := []byte(s)
+	for i := len(f.Prefix); i < end; i++ {
+		switch buf[i] {
+		case 'I', 'L':
+			buf[i] = '1'
+		case 'O':
+			buf[i] = '0'
+		}
+	}
+	return string(buf)
+}
+
+// Validate 校验 ID 是否属于本格式：前缀、字符集、长度以及校验字符。
+// 输入允许带分隔符、小写，先经过 Normalize。
+func (f Format) Validate(id string) error {
+	s := f.Normalize(id)
+	if !strings.HasPrefix(s, f.Prefix) {
+		return ErrPrefixMismatch
+	}
+	body := s[len(f.Prefix):]
+	if f.Check != CheckNone {
+		if len(body) < 2 {
+			return ErrMalformed
+		}
+		body = body[:len(body)-1]
+	}
+	if !f.bodyValid(body) {
+		return ErrMalformed
+	}
+	if f.Check != CheckNone {
+		want, ok := f.Check.compute(s[:len(s)-1])
+		if !ok {
+			return ErrMalformed
+		}
+		if s[len(s)-1] != want {
+			return ErrChecksum
+		}
+	}
+	return nil
+}
+
+// bodyValid 检查主体（不含前缀与校验字符）的字符集和长度。
+func (f Format) bodyValid(body string) bool {
+	switch f.Encoding {
+	case EncodingBase26:
+		// 至少 1 位时间字母 + 1 位数字；字母段在前，数字段在后
+		i := 0
+		for i < len(body) && body[i] >= 'A' && body[i] <= 'Z' {
+			i++
+		}
+		if i == 0 || i > 8 || i == len(body) {
+			return false
+		}
+		for ; i < len(body); i++ {
+			if body[i] < '0' || body[i] > '9' {
+				return false
+			}
+		}
+		return true
+	case EncodingCrockford:
+		if len(body) != crockfordTimeWidth+crockfordSeqWidth {
+			return false
+		}
+		for i := 0; i < len(body); i++ {
+			if strings.IndexByte(crockfordAlphabet, body[i]) < 0 {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// SnowflakeID 从 ID 中取回雪花段。ID 必须先通过 Validate。
+func (f Format) SnowflakeID(id string) (int64, error) {
+	if err := f.Validate(id); err != nil {
+		return 0, err
+	}
+	s := f.Normalize(id)
+	body := s[len(f.Prefix):]
+	if f.Check != CheckNone {
+		body = body[:len(body)-1]
+	}
+	switch f.Encoding {
+	case EncodingCrockford:
+		n, ok := decodeCrockford(body[crockfordTimeWidth:])
+		if !ok {
+			return 0, ErrMalformed
+		}
+		return int64(n), nil
+	default:
+		i := strings.IndexAny(body, "0123456789")
+		return strconv.ParseInt(body[i:], 10, 64)
+	}
+}
+
+// timeDigits 把 UTC 时间的「年(2)月(2)日(2)时(2)分(2)」拼成 10 位十进制数字。
+func timeDigits(t time.Time) uint64 {
+	yy := uint64(t.Year() % 100)
+	return yy*100000000 +
+		uint64(t.Month())*1000000 +
+		uint64(t.Day())*10000 +
+		uint64(t.Hour())*100 +
+		uint64(t.Minute())
+}
+
+// encodeCrockford 把 n 编码为定长 width 的 Crockford base32 串，左侧补 '0'。
+func encodeCrockford(n uint64, width int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[n&31]
+		n >>= 5
+	}
+	return string(buf)
+}
+
+// decodeCrockford 解码已规范化（大写、无 I/L/O/U）的 Crockford base32 串。
+func decodeCrockford(s string) (uint64, bool) {
+	if len(s) > 12 {
+		return 0, false
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(crockfordAlphabet, s[i])
+		if v < 0 {
+			return 0, false
+		}
+		n = n<<5 | uint64(v)
+	}
+	return n, true
+}
@@ -0,0 +1,164 @@
+package bizid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckDigitKnownVectors(t *testing.T) {
+	cases := []struct {
+		check   CheckDigit
+		payload string
+		want    byte
+	}{
+		{CheckLuhn, "7992739871", '3'},
+		{CheckDamm, "572", '4'},
+		{CheckISO7064, "A12425GABC1234002", 'M'}, // GRid 示例
+	}
+	for _, tc := range cases {
+		got, ok := tc.check.compute(tc.payload)
+		if !ok || got != tc.want {
+			t.Fatalf("%s(%q) = %q, want %q", tc.check, tc.payload, got, tc.want)
+		}
+	}
+}
+
+func TestCheckDigitCatchesSingleCharErrors(t *testing.T) {
+	fixed := time.Date(2026, 5, 22, 14, 30, 0, 0, time.UTC)
+	for _, check := range []CheckDigit{CheckLuhn, CheckDamm, CheckISO7064} {
+		f := Format{Prefix: "WD", Encoding: EncodingCrockford, Check: check}
+		id, err := f.build(fixed, 543304808)
+		if err != nil {
+			t.Fatalf("%s build: %v", check, err)
+		}
+		if err := f.Validate(id); err != nil {
+			t.Fatalf("%s: fresh id %q should validate: %v", check, id, err)
+		}
+		// 篡改主体中的一位
+		b := []byte(id)
+		pos := len(f.Prefix) + 3
+		if b[pos] == '7' {
+			b[pos] = '8'
+		} else {
+			b[pos] = '7'
+		}
+		if err := f.Validate(string(b)); !errors.Is(err, ErrChecksum) {
+			t.Fatalf("%s: tampered id %q should fail checksum, got %v", check, b, err)
+		}
+	}
+}
+
+// 验证文档中的保证：Luhn / Damm 针对纯数字主体，ISO 7064 针对字母数字混合主体。
+func TestCheckDigitGuarantees(t *testing.T) {
+	cases := []struct {
+		check    CheckDigit
+		payload  string
+		alphabet string
+		swaps    bool // 是否保证拦截全部相邻互换
+	}{
+		{CheckLuhn, "4780235196", "0123456789", false},
+		{CheckDamm, "4780235196", "0123456789", true},
+		{CheckISO7064, "WD7K2Q9ZX4M1", iso7064Alphabet, true},
+	}
+	for _, tc := range cases {
+		want, _ := tc.check.compute(tc.payload)
+		for i := range len(tc.payload) {
+			for j := range len(tc.alphabet) {
+				c := tc.alphabet[j]
+				if c == tc.payload[i] {
+					continue
+				}
+				b := []byte(tc.payload)
+				b[i] = c
+				if got, _ := tc.check.compute(string(b)); got == want {
+					t.Errorf("%s: substitution %q -> %q undetected", tc.check, tc.payload, b)
+				}
+			}
+			if !tc.swaps || i == len(tc.payload)-1 || tc.payload[i] == tc.payload[i+1] {
+				continue
+			}
+			b := []byte(tc.payload)
+			b[i], b[i+1] = b[i+1], b[i]
+			if got, _ := tc.check.compute(string(b)); got == want {
+				t.Errorf("%s: transposition %q -> %q undetected", tc.check, tc.payload, b)
+			}
+		}
+	}
+}
+
+func TestFormatDefaultMatchesLegacyNew(t *testing.T) {
+	fixed := time.Date(2026, 5, 22, 14, 30, 0, 0, time.UTC)
+	got, err := Format{Prefix: "RG"}.build(fixed, 543304808)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if want := "RG" + timeBase26(fixed) + "543304808"; got != want {
+		t.Fatalf("default format = %q, want %q", got, want)
+	}
+}
+
+func TestFormatGroupingAndNormalize(t *testing.T) {
+	fixed := time.Date(2026, 5, 22, 14, 30, 0, 0, time.UTC)
+	f := Format{Prefix: "WD", Encoding: EncodingCrockford, Check: CheckDamm, GroupSize: 4, Separator: "-"}
+	id, err := f.build(fixed, 543304808)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	parts := strings.Split(id, "-")
+	if parts[0] != "WD" || len(parts) != 6 || len(parts[1]) != 4 {
+		t.Fatalf("unexpected grouping: %q", id)
+	}
+
+	// 模拟客服录入：小写、空格代替横线、0 误打成 o
+	typed := strings.ToLower(strings.ReplaceAll(id, "-", " "))
+	typed = strings.Replace(typed, "0", "o", 1)
+	if err := f.Validate(typed); err != nil {
+		t.Fatalf("normalized input %q should validate: %v", typed, err)
+	}
+	sf, err := f.SnowflakeID(typed)
+	if err != nil || sf != 543304808 {
+		t.Fatalf("SnowflakeID(%q) = %d, %v", typed, sf, err)
+	}
+}
+
+func TestRegistryLookupAndValidate(t *testing.T) {
+	resetForTest()
+	if err := Init(1); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	const kind = "refund"
+	if err := Register(kind, Format{Prefix: "RF", Check: CheckISO7064, GroupSize: 5, Separator: " "}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	defer func() {
+		registryMu.Lock()
+		delete(registry, kind)
+		registryMu.Unlock()
+	}()
+
+	id := MustGenerate(kind)
+	got, err := Validate(id)
+	if err != nil || got != kind {
+		t.Fatalf("Validate(%q) = %q, %v", id, got, err)
+	}
+	if _, err := Validate("ZZ123"); !errors.Is(err, ErrUnknownPrefix) {
+		t.Fatalf("expected ErrUnknownPrefix, got %v", err)
+	}
+	if _, err := Generate("nope"); !errors.Is(err, ErrUnknownKind) {
+		t.Fatalf("expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestRegisterRejectsConflictingPrefix(t *testing.T) {
+	if err := Register("recharge2", Format{Prefix: "RG"}); err == nil {
+		t.Fatalf("expected duplicate prefix to be rejected")
+	}
+	if err := Register("recharge3", Format{Prefix: "RGX"}); err == nil {
+		t.Fatalf("expected prefix overlapping RG to be rejected")
+	}
+	if err := Register("lower", Format{Prefix: "rg"}); err == nil {
+		t.Fatalf("expected lower-case prefix to be rejected")
+	}
+}
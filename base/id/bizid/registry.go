@@ -0,0 +1,146 @@
+package bizid
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 内置业务种类。init 时按旧的硬编码前缀注册，格式与 New(prefix) 一致；
+// 项目可在启动时用 Register 覆盖为带校验字符 / 分组的格式。
+const (
+	KindRecharge = "recharge" // RG 充值订单
+	KindWithdraw = "withdraw" // WD 提款单
+	KindDeposit  = "deposit"  // DP 存款订单
+	KindBuy      = "buy"      // BU 购买 / 消费订单
+	KindExchange = "exchange" // EX 兑换订单
+	KindBonus    = "bonus"    // BO 积分订单
+	KindGem      = "gem"      // GM 宝石订单
+	KindGold     = "gold"     // GD 金币订单
+)
+
+var (
+	// ErrUnknownKind 业务种类未注册。
+	ErrUnknownKind = errors.New("bizid: unknown kind")
+	// ErrUnknownPrefix ID 前缀不属于任何已注册的业务种类。
+	ErrUnknownPrefix = errors.New("bizid: unknown prefix")
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Format{}
+)
+
+func init() {
+	for kind, prefix := range map[string]string{
+		KindRecharge: "RG",
+		KindWithdraw: "WD",
+		KindDeposit:  "DP",
+		KindBuy:      "BU",
+		KindExchange: "EX",
+		KindBonus:    "BO",
+		KindGem:      "GM",
+		KindGold:     "GD",
+	} {
+		MustRegister(kind, Format{Prefix: prefix})
+	}
+}
+
+// Register 注册（或替换）业务种类的格式。前缀只能是 1-4 个大写字母，
+// 且不能与其它种类的前缀相同或互为前缀——否则 Lookup 无法从 ID 反查种类。
+func Register(kind string, f Format) error {
+	if kind == "" {
+		return errors.New("bizid: empty kind")
+	}
+	if len(f.Prefix) == 0 || len(f.Prefix) > 4 {
+		return fmt.Errorf("bizid: prefix %q must be 1-4 letters", f.Prefix)
+	}
+	for i := 0; i < len(f.Prefix); i++ {
+		if f.Prefix[i] < 'A' || f.Prefix[i] > 'Z' {
+			return fmt.Errorf("bizid: prefix %q must be upper-case A-Z", f.Prefix)
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for k, other := range registry {
+		if k == kind {
+			continue
+		}
+		if strings.HasPrefix(other.Prefix, f.Prefix) || strings.HasPrefix(f.Prefix, other.Prefix) {
+			return fmt.Errorf("bizid: prefix %q of %q conflicts with %q of %q", f.Prefix, kind, other.Prefix, k)
+		}
+	}
+	registry[kind] = f
+	return nil
+}
+
+// MustRegister 同 Register，失败时 panic。适合放在包级 init。
+func MustRegister(kind string, f Format) {
+	if err := Register(kind, f); err != nil {
+		panic(err)
+	}
+}
+
+// FormatOf 返回业务种类当前的格式。
+func FormatOf(kind string) (Format, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	f, ok := registry[kind]
+	return f, ok
+}
+
+// Kinds 返回所有已注册的业务种类（按名称排序）。
+func Kinds() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]string, 0, len(registry))
+	for k := range registry {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Generate 按业务种类的注册格式生成 ID。
+func Generate(kind string) (string, error) {
+	f, ok := FormatOf(kind)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+	return f.New()
+}
+
+// MustGenerate 同 Generate，失败时 panic。
+func MustGenerate(kind string) string {
+	s, err := Generate(kind)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Lookup 根据 ID 前缀反查业务种类与格式，不做校验。输入允许带分隔符、小写。
+func Lookup(id string) (kind string, f Format, ok bool) {
+	s := strings.ToUpper(strings.TrimSpace(id))
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for k, candidate := range registry {
+		if strings.HasPrefix(s, candidate.Prefix) {
+			return k, candidate, true
+		}
+	}
+	return "", Format{}, false
+}
+
+// Validate 反查 ID 所属业务种类并校验前缀、字符集和校验字符。
+// 客服录入单号后调用它即可区分「不存在的业务」和「打错了一位」。
+func Validate(id string) (kind string, err error) {
+	kind, f, ok := Lookup(id)
+	if !ok {
+		return "", ErrUnknownPrefix
+	}
+	return kind, f.Validate(id)
+}
@@ -0,0 +1,247 @@
+// Package publicid 对外暴露的混淆 ID：把 int64 雪花 ID 编码为适合放进 URL 的短串，并能无损解码回去。
+//
+// 每个实体（user / order / ...）注册独立的 Codec：字母表按 salt 确定性打乱，
+// 所以同一个整数在不同实体下编码结果不同，用户 ID 串拿去当订单 ID 解码通常会失败
+// （Decode 只接受规范形式，碰巧也是另一实体规范编码的概率很低，但不为零，不能代替权限校验）。
+// 底层是 sqids，带屏蔽词表与最小长度。
+//
+//	publicid.MustRegister("user", publicid.Options{Salt: "user-2025", MinLength: 8})
+//	publicid.MustRegister("order", publicid.Options{Salt: "order-2025", MinLength: 10})
+//
+//	s, _ := publicid.Encode("user", 543304808)  // 例：Xk2pQ9aL
+//	id, err := publicid.Decode("user", s)       // 543304808
+//
+//	// 生成主键的同时拿到对外 ID（雪花取自 bizid 单例，需先 bizid.Init）
+//	id, pub, err := publicid.Next("order")
+package publicid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sqids/sqids-go"
+
+	"github.com/bizvip/go-utils/base/id/bizid"
+)
+
+// DefaultAlphabet 未指定字母表时使用的字符集（sqids 默认字母表）。
+const DefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+var (
+	// ErrNegative 负数无法编码。
+	ErrNegative = errors.New("publicid: negative id")
+	// ErrInvalid 串不是本实体 Codec 编码出来的规范形式。
+	ErrInvalid = errors.New("publicid: invalid id")
+	// ErrUnknownEntity 实体未注册。
+	ErrUnknownEntity = errors.New("publicid: unknown entity")
+)
+
+// Options 单个实体的编码参数。
+type Options struct {
+	// Alphabet 字符集，为空使用 DefaultAlphabet。只能是单字节字符且不可重复，至少 3 个。
+	Alphabet string
+	// Salt 按 salt 确定性打乱字母表。**上线后不可修改**，否则已发出的 ID 全部无法解码。
+	Salt string
+	// MinLength 编码结果的最小长度。
+	MinLength uint8
+	// Blocklist 追加到 sqids 默认屏蔽词表的词，命中的编码结果会自动重新生成。
+	Blocklist []string
+}
+
+// Codec 单个实体的编解码器，并发安全。
+type Codec struct {
+	entity string
+	s      *sqids.Sqids
+}
+
+// New 按 Options 创建 Codec。entity 仅用于错误信息与 Next。
+func New(entity string, opts Options) (*Codec, error) {
+	alphabet := opts.Alphabet
+	if alphabet == "" {
+		alphabet = DefaultAlphabet
+	}
+	s, err := sqids.New(sqids.Options{
+		Alphabet:  saltAlphabet(alphabet, opts.Salt),
+		MinLength: opts.MinLength,
+		Blocklist: sqids.Blocklist(opts.Blocklist...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("publicid: %s: %w", entity, err)
+	}
+	return &Codec{entity: entity, s: s}, nil
+}
+
+// Entity 返回实体名。
+func (c *Codec) Entity() string { return c.entity }
+
+// Encode 把非负 int64 编码为对外 ID。
+func (c *Codec) Encode(id int64) (string, error) {
+	if id < 0 {
+		return "", ErrNegative
+	}
+	s, err := c.s.Encode([]uint64{uint64(id)})
+	if err != nil {
+		return "", fmt.Errorf("publicid: %s: encode %d: %w", c.entity, id, err)
+	}
+	return s, nil
+}
+
+// MustEncode 同 Encode，失败时 panic。
+func (c *Codec) MustEncode(id int64) string {
+	s, err := c.Encode(id)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Decode 把对外 ID 解码回 int64。
+// sqids 对同一个数允许多种非规范写法，这里会重新编码比对，只接受规范形式——
+// 保证一个整数只对应一个 URL，也让别的实体的 ID 串解码失败。
+func (c *Codec) Decode(s string) (int64, error) {
+	if s == "" {
+		return 0, ErrInvalid
+	}
+	nums := c.s.Decode(s)
+	if len(nums) != 1 || nums[0] > 1<<63-1 {
+		return 0, ErrInvalid
+	}
+	canonical, err := c.s.Encode(nums)
+	if err != nil || canonical != s {
+		return 0, ErrInvalid
+	}
+	return int64(nums[0]), nil
+}
+
+// Next 从 bizid 的雪花单例取一个新 ID，同时返回其对外编码。
+func (c *Codec) Next() (id int64, public string, err error) {
+	id, err = bizid.GetSnowflakeID()
+	if err != nil {
+		return 0, "", err
+	}
+	public, err = c.Encode(id)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, public, nil
+}
+
+// saltAlphabet 按 salt 对字母表做 Fisher-Yates 洗牌，随机数取自 saltStream。
+// 洗牌只依赖 SHA-256 与本文件中的取模算法，不依赖 math/rand 等实现可能变化的库，
+// 因此同一 salt 在任何 Go 版本下都得到相同的字母表。
+func saltAlphabet(alphabet, salt string) string {
+	if salt == "" {
+		return alphabet
+	}
+	st := saltStream{seed: sha256.Sum256([]byte(salt))}
+	b := []byte(alphabet)
+	for i := len(b) - 1; i > 0; i-- {
+		j := st.intN(uint32(i + 1))
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// saltStream 确定性的字节流：第 k 块为 SHA-256(seed || k)，k 为 8 字节大端计数器。
+type saltStream struct {
+	seed    [sha256.Size]byte
+	counter uint64
+	block   [sha256.Size]byte
+	off     int
+}
+
+func (s *saltStream) uint32() uint32 {
+	if s.counter == 0 || s.off == len(s.block) {
+		var in [sha256.Size + 8]byte
+		copy(in[:], s.seed[:])
+		binary.BigEndian.PutUint64(in[sha256.Size:], s.counter)
+		s.block = sha256.Sum256(in[:])
+		s.counter++
+		s.off = 0
+	}
+	v := binary.BigEndian.Uint32(s.block[s.off:])
+	s.off += 4
+	return v
+}
+
+// intN 返回 [0, n) 内的均匀随机数：拒绝落在 2^32 除以 n 余数区间内的值，避免取模偏差。
+func (s *saltStream) intN(n uint32) uint32 {
+	limit := -n % n // 2^32 mod n
+	for {
+		if v := s.uint32(); v >= limit {
+			return v % n
+		}
+	}
+}
+
+// ----- 进程级实体注册表 -----
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]*Codec{}
+)
+
+// Register 为实体注册（或替换）Codec。
+func Register(entity string, opts Options) (*Codec, error) {
+	if entity == "" {
+		return nil, errors.New("publicid: empty entity")
+	}
+	c, err := New(entity, opts)
+	if err != nil {
+		return nil, err
+	}
+	mu.Lock()
+	codecs[entity] = c
+	mu.Unlock()
+	return c, nil
+}
+
+// MustRegister 同 Register，失败时 panic。适合在启动入口调用。
+func MustRegister(entity string, opts Options) *Codec {
+	c, err := Register(entity, opts)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Get 返回已注册实体的 Codec。
+func Get(entity string) (*Codec, error) {
+	mu.RLock()
+	c, ok := codecs[entity]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEntity, entity)
+	}
+	return c, nil
+}
+
+// Encode 用实体的 Codec 编码。
+func Encode(entity string, id int64) (string, error) {
+	c, err := Get(entity)
+	if err != nil {
+		return "", err
+	}
+	return c.Encode(id)
+}
+
+// Decode 用实体的 Codec 解码。
+func Decode(entity, s string) (int64, error) {
+	c, err := Get(entity)
+	if err != nil {
+		return 0, err
+	}
+	return c.Decode(s)
+}
+
+// Next 为实体生成新的雪花 ID 及其对外编码。
+func Next(entity string) (id int64, public string, err error) {
+	c, err := Get(entity)
+	if err != nil {
+		return 0, "", err
+	}
+	return c.Next()
+}
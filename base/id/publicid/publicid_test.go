@@ -0,0 +1,98 @@
+package publicid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizvip/go-utils/base/id/bizid"
+)
+
+func TestRoundTripSnowflakeRange(t *testing.T) {
+	c, err := New("user", Options{Salt: "user-salt", MinLength: 8})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, id := range []int64{0, 1, 42, 543304808, 1<<48 - 1, 1<<63 - 1} {
+		s, err := c.Encode(id)
+		if err != nil {
+			t.Fatalf("Encode(%d): %v", id, err)
+		}
+		if len(s) < 8 {
+			t.Fatalf("Encode(%d) = %q shorter than MinLength", id, s)
+		}
+		got, err := c.Decode(s)
+		if err != nil || got != id {
+			t.Fatalf("Decode(%q) = %d, %v; want %d", s, got, err, id)
+		}
+	}
+	if _, err := c.Encode(-1); !errors.Is(err, ErrNegative) {
+		t.Fatalf("expected ErrNegative, got %v", err)
+	}
+}
+
+func TestEntitiesDoNotShareSpace(t *testing.T) {
+	users, _ := New("user", Options{Salt: "user-salt", MinLength: 8})
+	orders, _ := New("order", Options{Salt: "order-salt", MinLength: 8})
+
+	const id = 543304808
+	u, o := users.MustEncode(id), orders.MustEncode(id)
+	if u == o {
+		t.Fatalf("different salts produced the same id %q", u)
+	}
+	if got, err := orders.Decode(u); err == nil && got == id {
+		t.Fatalf("order codec accepted user id %q", u)
+	}
+}
+
+func TestSaltIsDeterministic(t *testing.T) {
+	a, b := saltAlphabet(DefaultAlphabet, "x"), saltAlphabet(DefaultAlphabet, "x")
+	if a != b || a == DefaultAlphabet {
+		t.Fatalf("saltAlphabet should be a stable permutation, got %q / %q", a, b)
+	}
+}
+
+// 固定 salt 的洗牌结果。这些值一旦上线就不能变，否则已发出的 ID 无法解码；
+// 期望值由独立实现（Python hashlib）按 SHA-256 计数器流 + 拒绝采样算出。
+func TestSaltAlphabetGolden(t *testing.T) {
+	cases := []struct{ alphabet, salt, want string }{
+		{DefaultAlphabet, "user-salt", "R8qx0WNDzacnHGFsQVOMYtC6L4Bo5w1XKyjmblZ23EgUdfT9pIuPhiekvASrJ7"},
+		{"0123456789", "order-2025", "0439856271"},
+		{DefaultAlphabet, "", DefaultAlphabet},
+	}
+	for _, tc := range cases {
+		if got := saltAlphabet(tc.alphabet, tc.salt); got != tc.want {
+			t.Errorf("saltAlphabet(%q) = %q, want %q", tc.salt, got, tc.want)
+		}
+	}
+}
+
+func TestBlocklistAvoidsWord(t *testing.T) {
+	plain, _ := New("plain", Options{Alphabet: "abc"})
+	word := plain.MustEncode(7)
+	blocked, _ := New("blocked", Options{Alphabet: "abc", Blocklist: []string{word}})
+	s := blocked.MustEncode(7)
+	if s == word {
+		t.Fatalf("blocklisted word %q was emitted", word)
+	}
+	if got, err := blocked.Decode(s); err != nil || got != 7 {
+		t.Fatalf("Decode(%q) = %d, %v", s, got, err)
+	}
+}
+
+func TestRegistryNext(t *testing.T) {
+	if err := bizid.Init(2); err != nil {
+		t.Fatalf("bizid.Init: %v", err)
+	}
+	MustRegister("invoice", Options{Salt: "invoice", MinLength: 6})
+	id, pub, err := Next("invoice")
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	got, err := Decode("invoice", pub)
+	if err != nil || got != id {
+		t.Fatalf("Decode(%q) = %d, %v; want %d", pub, got, err, id)
+	}
+	if _, err := Encode("missing", 1); !errors.Is(err, ErrUnknownEntity) {
+		t.Fatalf("expected ErrUnknownEntity, got %v", err)
+	}
+}
@@ -20,11 +20,13 @@ func init() {
 	}
 }
 
+// ToAlpha 用包级固定字母表编码一组整数。对外暴露单个雪花 ID 请用 base/id/publicid。
 func ToAlpha(ids []uint64) string {
 	id, _ := s.Encode(ids)
 	return id
 }
 
+// ToInt 解码 ToAlpha 的结果。
 func ToInt(ids string) []uint64 {
 	return s.Decode(ids)
 }
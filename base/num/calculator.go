@@ -11,7 +11,10 @@ import (
 )
 
 // Calculator 高级表达式计算器
-type Calculator struct{}
+// Evaluate 以 float64 计算；涉及金额时使用 EvaluateDecimal / Compile，以 decimal 精确计算。
+type Calculator struct {
+	DivisionPrecision int32 // decimal 模式下除法保留的小数位数，0 表示使用默认的 16 位
+}
 
 // NewCalculator 创建计算器实例
 func NewCalculator() *Calculator {
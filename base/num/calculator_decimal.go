@@ -0,0 +1,441 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// -----------------------------
+// decimal 模式：变量、函数与规则表达式
+// -----------------------------
+//
+// 语法沿用 Go 表达式：
+//   - 算术 + - * / %，比较 == != < <= > >=，逻辑 && || !
+//   - 变量：price*qty*(1-discount)，true / false 为布尔字面量
+//   - 函数：min(a, b, ...)、max(a, b, ...)、abs(x)、round(x[, n])、floor(x[, n])、ceil(x[, n])，
+//     round 为四舍五入（远离零），n 为保留的小数位数，取 0 到 32 的整数，默认 0
+//   - 数字字面量与字符串变量的十进制指数须在 ±64 以内，如 1e99999999 会被拒绝
+//
+// 表达式只解析一次，Compile 得到的 *Expr 可并发地以不同变量多次求值。
+
+const defaultDivisionPrecision = 16
+
+const (
+	maxExponent = 64 // 由文本解析的数字允许的最大十进制指数（绝对值）
+	maxPlaces   = 32 // round / floor / ceil 允许的最大小数位数
+)
+
+var operatorReplacer = strings.NewReplacer("×", "*", "÷", "/")
+
+// ErrUndefinedVariable 求值时缺少变量。
+var ErrUndefinedVariable = errors.New("未定义的变量")
+
+// Value 表达式的值：数值或布尔值。
+type Value struct {
+	Num    decimal.Decimal
+	Bool   bool
+	IsBool bool
+}
+
+func numValue(d decimal.Decimal) Value { return Value{Num: d} }
+func boolValue(b bool) Value           { return Value{Bool: b, IsBool: true} }
+
+func (v Value) String() string {
+	if v.IsBool {
+		return fmt.Sprint(v.Bool)
+	}
+	return v.Num.String()
+}
+
+// exprFunc 内置函数，minArgs / maxArgs 用于编译期检查参数个数，maxArgs 为 -1 表示不限。
+type exprFunc struct {
+	minArgs, maxArgs int
+	call             func(args []decimal.Decimal) (decimal.Decimal, error)
+}
+
+// placesArg 取可选的小数位数参数，须为 0 到 maxPlaces 的整数。
+func placesArg(args []decimal.Decimal) (int32, error) {
+	if len(args) < 2 {
+		return 0, nil
+	}
+	n := args[1]
+	if !n.IsInteger() || n.IsNegative() || n.GreaterThan(decimal.NewFromInt(maxPlaces)) {
+		return 0, fmt.Errorf("小数位数须为 0 到 %d 的整数: %s", maxPlaces, n)
+	}
+	return int32(n.IntPart()), nil
+}
+
+// roundFunc 以 placesArg 取小数位数后调用 fn。
+func roundFunc(fn func(x decimal.Decimal, places int32) decimal.Decimal) func([]decimal.Decimal) (decimal.Decimal, error) {
+	return func(a []decimal.Decimal) (decimal.Decimal, error) {
+		p, err := placesArg(a)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return fn(a[0], p), nil
+	}
+}
+
+var exprFuncs = map[string]exprFunc{
+	"min": {1, -1, func(a []decimal.Decimal) (decimal.Decimal, error) { return decimal.Min(a[0], a[1:]...), nil }},
+	"max": {1, -1, func(a []decimal.Decimal) (decimal.Decimal, error) { return decimal.Max(a[0], a[1:]...), nil }},
+	"abs": {1, 1, func(a []decimal.Decimal) (decimal.Decimal, error) { return a[0].Abs(), nil }},
+	"round": {1, 2, roundFunc(func(x decimal.Decimal, p int32) decimal.Decimal {
+		return x.Round(p)
+	})},
+	"floor": {1, 2, roundFunc(func(x decimal.Decimal, p int32) decimal.Decimal {
+		return x.Shift(p).Floor().Shift(-p)
+	})},
+	"ceil": {1, 2, roundFunc(func(x decimal.Decimal, p int32) decimal.Decimal {
+		return x.Shift(p).Ceil().Shift(-p)
+	})},
+}
+
+// parseNumber 解析文本形式的数字，拒绝指数过大或过小的值：
+// decimal 的加减需要对齐指数，1e99999999+1 会分配上亿位的整数。
+func parseNumber(s string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("无效的数字: %s", s)
+	}
+	if exp := d.Exponent(); exp > maxExponent || exp < -maxExponent {
+		return decimal.Zero, fmt.Errorf("数字超出范围: %s", s)
+	}
+	return d, nil
+}
+
+// Expr 编译后的表达式，不可变，可并发使用。
+type Expr struct {
+	source    string
+	node      ast.Expr
+	vars      []string
+	precision int32
+}
+
+// Compile 解析并检查表达式：语法、运算符、函数名与参数个数在此阶段报错，变量在求值时绑定。
+func (c *Calculator) Compile(expression string) (*Expr, error) {
+	// 不像 cleanExpression 那样删除空格，避免 "a b" 被当作变量 ab
+	node, err := parser.ParseExpr(operatorReplacer.Replace(expression))
+	if err != nil {
+		return nil, fmt.Errorf("无效的表达式: %w", err)
+	}
+	e := &Expr{source: expression, node: node, precision: c.DivisionPrecision}
+	if e.precision <= 0 {
+		e.precision = defaultDivisionPrecision
+	}
+	seen := map[string]bool{}
+	if err := e.check(node, seen); err != nil {
+		return nil, err
+	}
+	for v := range seen {
+		e.vars = append(e.vars, v)
+	}
+	sort.Strings(e.vars)
+	return e, nil
+}
+
+// EvaluateDecimal 以 decimal 计算数值表达式，vars 的值可为 decimal.Decimal、整数、float64、数字字符串或 bool。
+func (c *Calculator) EvaluateDecimal(expression string, vars map[string]any) (decimal.Decimal, error) {
+	e, err := c.Compile(expression)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return e.EvalDecimal(vars)
+}
+
+// EvaluateBool 计算规则表达式，如 "amount >= 1000 && level > 2"。
+func (c *Calculator) EvaluateBool(expression string, vars map[string]any) (bool, error) {
+	e, err := c.Compile(expression)
+	if err != nil {
+		return false, err
+	}
+	return e.EvalBool(vars)
+}
+
+// Compile 使用全局计算器编译表达式。
+func Compile(expression string) (*Expr, error) {
+	return globalCalculator.Compile(expression)
+}
+
+// EvaluateDecimal 使用全局计算器以 decimal 计算。
+func EvaluateDecimal(expression string, vars map[string]any) (decimal.Decimal, error) {
+	return globalCalculator.EvaluateDecimal(expression, vars)
+}
+
+// String 原始表达式。
+func (e *Expr) String() string { return e.source }
+
+// Vars 表达式引用的变量名（排序后）。
+func (e *Expr) Vars() []string { return append([]string(nil), e.vars...) }
+
+// Eval 求值。
+func (e *Expr) Eval(vars map[string]any) (Value, error) {
+	return e.eval(e.node, vars)
+}
+
+// EvalDecimal 求值，结果须为数值。
+func (e *Expr) EvalDecimal(vars map[string]any) (decimal.Decimal, error) {
+	v, err := e.Eval(vars)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if v.IsBool {
+		return decimal.Zero, fmt.Errorf("表达式结果为布尔值，不是数值: %s", e.source)
+	}
+	return v.Num, nil
+}
+
+// EvalBool 求值，结果须为布尔值。
+func (e *Expr) EvalBool(vars map[string]any) (bool, error) {
+	v, err := e.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	if !v.IsBool {
+		return false, fmt.Errorf("表达式结果为数值，不是布尔值: %s", e.source)
+	}
+	return v.Bool, nil
+}
+
+// check 编译期检查节点，收集变量名。
+func (e *Expr) check(node ast.Expr, vars map[string]bool) error {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT {
+			return fmt.Errorf("不支持的字面量类型: %s", n.Value)
+		}
+		if _, err := parseNumber(n.Value); err != nil {
+			return err
+		}
+		return nil
+	case *ast.Ident:
+		if n.Name != "true" && n.Name != "false" {
+			vars[n.Name] = true
+		}
+		return nil
+	case *ast.ParenExpr:
+		return e.check(n.X, vars)
+	case *ast.UnaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.NOT:
+			return e.check(n.X, vars)
+		}
+		return fmt.Errorf("不支持的一元运算符: %s", n.Op)
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO, token.REM,
+			token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ,
+			token.LAND, token.LOR:
+		default:
+			return fmt.Errorf("不支持的运算符: %s", n.Op)
+		}
+		if err := e.check(n.X, vars); err != nil {
+			return err
+		}
+		return e.check(n.Y, vars)
+	case *ast.CallExpr:
+		ident, ok := n.Fun.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("不支持的函数调用")
+		}
+		fn, ok := exprFuncs[ident.Name]
+		if !ok {
+			return fmt.Errorf("未知的函数: %s", ident.Name)
+		}
+		if len(n.Args) < fn.minArgs || fn.maxArgs >= 0 && len(n.Args) > fn.maxArgs {
+			return fmt.Errorf("函数 %s 的参数个数错误: %d", ident.Name, len(n.Args))
+		}
+		if n.Ellipsis.IsValid() {
+			return fmt.Errorf("不支持的函数调用")
+		}
+		for _, arg := range n.Args {
+			if err := e.check(arg, vars); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("不支持的表达式类型")
+	}
+}
+
+func (e *Expr) eval(node ast.Expr, vars map[string]any) (Value, error) {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		d, _ := parseNumber(n.Value) // 已在编译期检查
+		return numValue(d), nil
+
+	case *ast.Ident:
+		switch n.Name {
+		case "true":
+			return boolValue(true), nil
+		case "false":
+			return boolValue(false), nil
+		}
+		raw, ok := vars[n.Name]
+		if !ok {
+			return Value{}, fmt.Errorf("%w: %s", ErrUndefinedVariable, n.Name)
+		}
+		return toValue(n.Name, raw)
+
+	case *ast.ParenExpr:
+		return e.eval(n.X, vars)
+
+	case *ast.UnaryExpr:
+		x, err := e.eval(n.X, vars)
+		if err != nil {
+			return Value{}, err
+		}
+		if n.Op == token.NOT {
+			if !x.IsBool {
+				return Value{}, fmt.Errorf("运算符 ! 需要布尔值")
+			}
+			return boolValue(!x.Bool), nil
+		}
+		if x.IsBool {
+			return Value{}, fmt.Errorf("运算符 %s 需要数值", n.Op)
+		}
+		if n.Op == token.SUB {
+			return numValue(x.Num.Neg()), nil
+		}
+		return x, nil
+
+	case *ast.BinaryExpr:
+		return e.evalBinary(n, vars)
+
+	case *ast.CallExpr:
+		name := n.Fun.(*ast.Ident).Name
+		args := make([]decimal.Decimal, len(n.Args))
+		for i, a := range n.Args {
+			v, err := e.eval(a, vars)
+			if err != nil {
+				return Value{}, err
+			}
+			if v.IsBool {
+				return Value{}, fmt.Errorf("函数 %s 的参数需要数值", name)
+			}
+			args[i] = v.Num
+		}
+		d, err := exprFuncs[name].call(args)
+		if err != nil {
+			return Value{}, fmt.Errorf("函数 %s: %w", name, err)
+		}
+		return numValue(d), nil
+	}
+	return Value{}, fmt.Errorf("不支持的表达式类型")
+}
+
+func (e *Expr) evalBinary(n *ast.BinaryExpr, vars map[string]any) (Value, error) {
+	x, err := e.eval(n.X, vars)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// 逻辑运算短路求值
+	if n.Op == token.LAND || n.Op == token.LOR {
+		if !x.IsBool {
+			return Value{}, fmt.Errorf("运算符 %s 需要布尔值", n.Op)
+		}
+		if n.Op == token.LAND && !x.Bool || n.Op == token.LOR && x.Bool {
+			return x, nil
+		}
+		y, err := e.eval(n.Y, vars)
+		if err != nil {
+			return Value{}, err
+		}
+		if !y.IsBool {
+			return Value{}, fmt.Errorf("运算符 %s 需要布尔值", n.Op)
+		}
+		return y, nil
+	}
+
+	y, err := e.eval(n.Y, vars)
+	if err != nil {
+		return Value{}, err
+	}
+	if n.Op == token.EQL || n.Op == token.NEQ {
+		if x.IsBool != y.IsBool {
+			return Value{}, fmt.Errorf("运算符 %s 两侧类型不一致", n.Op)
+		}
+		eq := x.Bool == y.Bool
+		if !x.IsBool {
+			eq = x.Num.Equal(y.Num)
+		}
+		return boolValue(eq == (n.Op == token.EQL)), nil
+	}
+	if x.IsBool || y.IsBool {
+		return Value{}, fmt.Errorf("运算符 %s 需要数值", n.Op)
+	}
+
+	a, b := x.Num, y.Num
+	switch n.Op {
+	case token.ADD:
+		return numValue(a.Add(b)), nil
+	case token.SUB:
+		return numValue(a.Sub(b)), nil
+	case token.MUL:
+		return numValue(a.Mul(b)), nil
+	case token.QUO:
+		if b.IsZero() {
+			return Value{}, fmt.Errorf("除零错误")
+		}
+		return numValue(a.DivRound(b, e.precision)), nil
+	case token.REM:
+		if b.IsZero() {
+			return Value{}, fmt.Errorf("除零错误")
+		}
+		return numValue(a.Mod(b)), nil
+	case token.LSS:
+		return boolValue(a.LessThan(b)), nil
+	case token.LEQ:
+		return boolValue(a.LessThanOrEqual(b)), nil
+	case token.GTR:
+		return boolValue(a.GreaterThan(b)), nil
+	case token.GEQ:
+		return boolValue(a.GreaterThanOrEqual(b)), nil
+	}
+	return Value{}, fmt.Errorf("不支持的运算符: %s", n.Op)
+}
+
+// toValue 把调用方传入的变量转换为 Value。
+func toValue(name string, raw any) (Value, error) {
+	switch v := raw.(type) {
+	case decimal.Decimal:
+		return numValue(v), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return Value{}, fmt.Errorf("变量 %s 为 nil", name)
+		}
+		return numValue(*v), nil
+	case bool:
+		return boolValue(v), nil
+	case int:
+		return numValue(decimal.NewFromInt(int64(v))), nil
+	case int32:
+		return numValue(decimal.NewFromInt32(v)), nil
+	case int64:
+		return numValue(decimal.NewFromInt(v)), nil
+	case uint32:
+		return numValue(decimal.NewFromUint64(uint64(v))), nil
+	case uint64:
+		return numValue(decimal.NewFromUint64(v)), nil
+	case float64:
+		return numValue(decimal.NewFromFloat(v)), nil
+	case string:
+		d, err := parseNumber(v)
+		if err != nil {
+			return Value{}, fmt.Errorf("变量 %s 不是有效的数字: %q", name, v)
+		}
+		return numValue(d), nil
+	case Money:
+		return numValue(v.Amount()), nil
+	}
+	return Value{}, fmt.Errorf("变量 %s 的类型不受支持: %T", name, raw)
+}
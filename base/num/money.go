@@ -0,0 +1,357 @@
+package num
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/consts/currencycode"
+)
+
+// Money 相关错误
+var (
+	ErrCurrencyMismatch = errors.New("money amounts differ in currency")
+	ErrUnknownCurrency  = errors.New("unknown currency code")
+	ErrAllocation       = errors.New("allocation ratios must be non-negative with a positive sum")
+)
+
+// Money 法币金额：decimal + currencycode 中的币种代码，金额始终按币种的小数位数（CNY 2 位、JPY 0 位）舍入。
+// 值类型、不可变，所有运算返回新值；与 TokenAmount 不同，Money 允许为负（退款、差额）。
+//
+//	price, _ := num.ParseMoney("1234.5", currencycode.CNY)
+//	parts, _ := price.Split(3) // 411.50 / 411.50 / 411.50
+//	price.Format("zh-CN")      // ¥1,234.50
+//
+// 零值 Money{} 表示未设置的金额（没有币种）：String 与 Format 均为 "0"，JSON 序列化为 null，
+// 反序列化 null 或币种为空、金额为空或 0 的对象得到零值。
+type Money struct {
+	amount   decimal.Decimal
+	currency string
+}
+
+// NewMoney 按币种小数位数以银行家舍入构造。
+func NewMoney(amount decimal.Decimal, currency string) (Money, error) {
+	return MoneyFromDecimal(amount, currency, RoundHalfEven)
+}
+
+// MoneyFromDecimal 按 mode 舍入到币种小数位数，RoundExact 时多余的小数返回 ErrAmountPrecision。
+func MoneyFromDecimal(amount decimal.Decimal, currency string, mode RoundingMode) (Money, error) {
+	c, ok := currencycode.Lookup(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	places := int32(c.MinorUnits)
+	if mode == RoundExact && !amount.Equal(amount.Truncate(places)) {
+		return Money{}, ErrAmountPrecision
+	}
+	return Money{amount: roundDecimal(amount, places, mode).Truncate(places), currency: c.Code}, nil
+}
+
+// ParseMoney 解析金额字符串，不允许超出币种小数位数。
+func ParseMoney(s, currency string) (Money, error) {
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return Money{}, ErrDecimalConversion
+	}
+	return MoneyFromDecimal(d, currency, RoundExact)
+}
+
+// MoneyFromMinor 由最小单位整数构造，如 (12345, CNY) 为 123.45 元。
+func MoneyFromMinor(minor int64, currency string) (Money, error) {
+	c, ok := currencycode.Lookup(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return Money{amount: decimal.New(minor, -int32(c.MinorUnits)), currency: c.Code}, nil
+}
+
+// ZeroMoney 指定币种的 0，常用作 sql.Scan 的目标；币种未登记时返回零值 Money。
+func ZeroMoney(currency string) Money {
+	m, _ := MoneyFromMinor(0, currency)
+	return m
+}
+
+// Amount 金额。
+func (m Money) Amount() decimal.Decimal { return m.amount }
+
+// Currency 币种代码。
+func (m Money) Currency() string { return m.currency }
+
+// Minor 最小单位整数，如 123.45 CNY 为 12345。
+func (m Money) Minor() *big.Int {
+	return m.amount.Shift(m.places()).BigInt()
+}
+
+// IsZero 是否为 0。
+func (m Money) IsZero() bool { return m.amount.IsZero() }
+
+// Sign 符号：负数 -1，0 为 0，正数 1。
+func (m Money) Sign() int { return m.amount.Sign() }
+
+func (m Money) places() int32 {
+	if m.currency == "" {
+		return 0
+	}
+	return int32(currencycode.MinorUnits(m.currency))
+}
+
+// SameCurrency 币种是否相同，只有相同才可以相互运算。
+func (m Money) SameCurrency(o Money) bool { return m.currency == o.currency }
+
+// Add 相加，币种不同返回 ErrCurrencyMismatch。
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{amount: m.amount.Add(o.amount), currency: m.currency}, nil
+}
+
+// Sub 相减，币种不同返回 ErrCurrencyMismatch。
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{amount: m.amount.Sub(o.amount), currency: m.currency}, nil
+}
+
+// Mul 乘以系数（数量、税率、汇率以外的比例），结果按 mode 舍入到币种小数位数。
+// 换算为其它币种请用汇率得到 decimal 后重新构造 Money。
+func (m Money) Mul(factor decimal.Decimal, mode RoundingMode) (Money, error) {
+	return MoneyFromDecimal(m.amount.Mul(factor), m.currency, mode)
+}
+
+// Neg 取反。
+func (m Money) Neg() Money { return Money{amount: m.amount.Neg(), currency: m.currency} }
+
+// Abs 绝对值。
+func (m Money) Abs() Money { return Money{amount: m.amount.Abs(), currency: m.currency} }
+
+// Cmp 比较大小：m<o 返回 -1，相等 0，m>o 返回 1；币种不同返回 ErrCurrencyMismatch。
+func (m Money) Cmp(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, ErrCurrencyMismatch
+	}
+	return m.amount.Cmp(o.amount), nil
+}
+
+// Equal 币种相同且数值相等。
+func (m Money) Equal(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c == 0
+}
+
+// Allocate 按比例分配，各份之和严格等于原金额：先按比例向零截断到最小单位，
+// 剩余的若干个最小单位依次补给前面的份额。例如 100 按 1:1:1 分为 33.34 / 33.33 / 33.33。
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrAllocation
+		}
+		total.Add(total, big.NewInt(int64(r)))
+	}
+	if total.Sign() == 0 {
+		return nil, ErrAllocation
+	}
+
+	minor := m.Minor()
+	places := m.places()
+	shares := make([]*big.Int, len(ratios))
+	remainder := new(big.Int).Set(minor)
+	for i, r := range ratios {
+		shares[i] = new(big.Int).Mul(minor, big.NewInt(int64(r)))
+		shares[i].Quo(shares[i], total)
+		remainder.Sub(remainder, shares[i])
+	}
+	unit := big.NewInt(int64(remainder.Sign()))
+	for i := 0; remainder.Sign() != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Add(shares[i], unit)
+		remainder.Sub(remainder, unit)
+	}
+
+	out := make([]Money, len(ratios))
+	for i, s := range shares {
+		out[i] = Money{amount: decimal.NewFromBigInt(s, -places), currency: m.currency}
+	}
+	return out, nil
+}
+
+// Split 平均分为 n 份，各份之和等于原金额。
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, ErrAllocation
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// String 如 "1234.50 CNY"。
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.amount.String()
+	}
+	return m.amount.StringFixed(m.places()) + " " + m.currency
+}
+
+// -----------------------------
+// 本地化格式
+// -----------------------------
+
+// moneyLocale 金额的本地化书写方式。
+type moneyLocale struct {
+	group       string // 千分位分隔符，法语等使用不换行空格
+	decimal     string // 小数点
+	symbolAfter bool   // 货币符号在数字之后
+	space       bool   // 符号与数字之间是否有空格
+}
+
+var moneyLocales = map[string]moneyLocale{
+	"en": {group: ",", decimal: "."},
+	"zh": {group: ",", decimal: "."},
+	"ja": {group: ",", decimal: "."},
+	"ko": {group: ",", decimal: "."},
+	"th": {group: ",", decimal: "."},
+	"de": {group: ".", decimal: ",", symbolAfter: true, space: true},
+	"es": {group: ".", decimal: ",", symbolAfter: true, space: true},
+	"it": {group: ".", decimal: ",", symbolAfter: true, space: true},
+	"nl": {group: ".", decimal: ",", space: true},
+	"pt": {group: ".", decimal: ",", space: true}, // 巴西写法 R$ 1.234,50
+	"id": {group: ".", decimal: ","},
+	"vi": {group: ".", decimal: ",", symbolAfter: true, space: true},
+	"tr": {group: ".", decimal: ","},
+	"fr": {group: "\u202f", decimal: ",", symbolAfter: true, space: true},
+	"ru": {group: "\u00a0", decimal: ",", symbolAfter: true, space: true},
+	"pl": {group: "\u00a0", decimal: ",", symbolAfter: true, space: true},
+	"sv": {group: "\u00a0", decimal: ",", symbolAfter: true, space: true},
+}
+
+// Format 按 locale（如 "zh-CN"、"en_US"、"de"）格式化，按语言部分匹配，未知语言按英文格式：
+//
+//	¥1,234.50（zh-CN）、1.234,50 €（de-DE）、-$1,234.50（en-US）
+func (m Money) Format(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	loc, ok := moneyLocales[lang]
+	if !ok {
+		loc = moneyLocales["en"]
+	}
+
+	digits := m.amount.Abs().StringFixed(m.places())
+	intPart, frac, _ := strings.Cut(digits, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(loc.group)
+		}
+		b.WriteRune(r)
+	}
+	number := b.String()
+	if frac != "" {
+		number += loc.decimal + frac
+	}
+
+	symbol := m.currency
+	if c, ok := currencycode.Lookup(m.currency); ok {
+		symbol = c.Symbol
+	}
+	sep := ""
+	if loc.space {
+		sep = " "
+	}
+	s := symbol + sep + number
+	if loc.symbolAfter {
+		s = number + sep + symbol
+	}
+	if m.amount.IsNegative() {
+		s = "-" + s
+	}
+	return s
+}
+
+// -----------------------------
+// JSON / SQL
+// -----------------------------
+
+// moneyJSON JSON 形式：金额用字符串避免 JS 精度丢失。
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON 输出 {"amount":"1234.50","currency":"CNY"}，零值输出 null。
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.currency == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(moneyJSON{Amount: m.amount.StringFixed(m.places()), Currency: m.currency})
+}
+
+// UnmarshalJSON 精确解析，金额超出币种小数位数时报错；null 与无币种的 0 得到零值。
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = Money{}
+		return nil
+	}
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Currency == "" {
+		if d, err := decimal.NewFromString(strings.TrimSpace(v.Amount)); v.Amount == "" || err == nil && d.IsZero() {
+			*m = Money{}
+			return nil
+		}
+	}
+	out, err := ParseMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = out
+	return nil
+}
+
+// Value 实现 driver.Valuer，存为定点小数字符串，列类型建议 NUMERIC(20,2) 或按币种调整。
+func (m Money) Value() (driver.Value, error) {
+	return m.amount.StringFixed(m.places()), nil
+}
+
+// Scan 实现 sql.Scanner，只读入金额，币种沿用接收者原有的值：
+//
+//	m := num.ZeroMoney(currencycode.CNY)
+//	err := row.Scan(&m)
+func (m *Money) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		s = "0"
+	case int64:
+		s = fmt.Sprint(v)
+	case float64:
+		s = decimal.NewFromFloat(v).String()
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("%w: %T", ErrAmountScanSource, src)
+	}
+	out, err := ParseMoney(s, m.currency)
+	if err != nil {
+		return err
+	}
+	*m = out
+	return nil
+}
@@ -37,6 +37,7 @@ func ValidateSecPwd(secPwd string) error {
 }
 
 // Int64ToHashId 将 int64 转换为 Sqids 编码的 hash ID
+// 所有调用共用 sqids 默认字母表；需要按实体隔离 ID 空间时改用 base/id/publicid。
 func Int64ToHashId(number int64, minLen uint8) string {
 	var ids []uint64
 	ids = append(ids, uint64(number))
@@ -59,6 +60,7 @@ func HashIdToInt64(id string, minLen uint8) (int64, error) {
 }
 
 // MergeToDecimal 如果输入的number是100000，dec是10，那么：将100000的小数点向左移动10位，得到的结果是0.00001
+// 需要带币种、可做加减比较的链上金额时用 TokenAmount。
 func MergeToDecimal(number *big.Int, dec int) decimal.Decimal {
 	decimalNumber := decimal.NewFromBigInt(number, 0)
 	divisor := decimal.NewFromFloat(math.Pow(10, float64(dec)))
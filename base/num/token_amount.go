@@ -0,0 +1,268 @@
+package num
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+// TokenAmount 相关错误
+var (
+	ErrTokenMismatch    = errors.New("token amounts differ in symbol or decimals")
+	ErrAmountOverflow   = errors.New("token amount exceeds uint256")
+	ErrAmountNegative   = errors.New("token amount must not be negative")
+	ErrAmountPrecision  = errors.New("token amount has more fractional digits than token decimals")
+	ErrAmountScanSource = errors.New("unsupported source type for token amount")
+)
+
+// MaxUint256 链上整数（uint256）的上限 2^256-1。
+var MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// RoundingMode 人类可读金额换算成链上整数、或格式化显示时的舍入方式。
+type RoundingMode int
+
+const (
+	RoundExact    RoundingMode = iota // 不允许舍入，超出精度时报 ErrAmountPrecision
+	RoundDown                         // 向零截断，提现/扣款时不多给
+	RoundUp                           // 远离零进位，收费时不少收
+	RoundHalfUp                       // 四舍五入
+	RoundHalfEven                     // 银行家舍入
+)
+
+// TokenAmount 链上代币金额：最小单位整数 + 精度 + 币种符号（如 cryptocurrency.USDT）。
+// 值类型、不可变，所有运算返回新值；取值范围 [0, 2^256-1]。
+//
+//	a, _ := num.ParseTokenAmount("12.5", 6, cryptocurrency.USDT) // raw = 12500000
+//	b := num.NewTokenAmountFromInt64(500000, 6, cryptocurrency.USDT)
+//	sum, _ := a.Add(b) // 13 USDT
+type TokenAmount struct {
+	raw      *big.Int
+	decimals uint8
+	symbol   string
+}
+
+// NewTokenAmount 由链上整数构造，raw 为 nil 视为 0；raw 会被复制。
+func NewTokenAmount(raw *big.Int, decimals uint8, symbol string) (TokenAmount, error) {
+	a := TokenAmount{raw: new(big.Int), decimals: decimals, symbol: symbol}
+	if raw != nil {
+		a.raw.Set(raw)
+	}
+	return a, a.check()
+}
+
+// NewTokenAmountFromInt64 由 int64 链上整数构造，负数按 0 处理；需要对负数报错时用 NewTokenAmount。
+func NewTokenAmountFromInt64(raw int64, decimals uint8, symbol string) TokenAmount {
+	return TokenAmount{raw: big.NewInt(max(raw, 0)), decimals: decimals, symbol: symbol}
+}
+
+// ZeroTokenAmount 指定币种的 0，常用作 sql.Scan 的目标。
+func ZeroTokenAmount(decimals uint8, symbol string) TokenAmount {
+	return TokenAmount{raw: new(big.Int), decimals: decimals, symbol: symbol}
+}
+
+// TokenAmountFromDecimal 把人类可读金额（如 1.5）按精度换算为链上整数，多余小数按 mode 处理。
+func TokenAmountFromDecimal(d decimal.Decimal, decimals uint8, symbol string, mode RoundingMode) (TokenAmount, error) {
+	shifted := d.Shift(int32(decimals))
+	if mode == RoundExact && !shifted.Equal(shifted.Truncate(0)) {
+		return TokenAmount{}, ErrAmountPrecision
+	}
+	return NewTokenAmount(roundDecimal(shifted, 0, mode).BigInt(), decimals, symbol)
+}
+
+// ParseTokenAmount 解析人类可读金额字符串，不允许超出代币精度。
+func ParseTokenAmount(s string, decimals uint8, symbol string) (TokenAmount, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return TokenAmount{}, ErrDecimalConversion
+	}
+	return TokenAmountFromDecimal(d, decimals, symbol, RoundExact)
+}
+
+func (a TokenAmount) check() error {
+	switch {
+	case a.raw.Sign() < 0:
+		return ErrAmountNegative
+	case a.raw.Cmp(MaxUint256) > 0:
+		return ErrAmountOverflow
+	}
+	return nil
+}
+
+// int 零值 TokenAmount 的 raw 为 nil，按 0 处理。
+func (a TokenAmount) int() *big.Int {
+	if a.raw == nil {
+		return new(big.Int)
+	}
+	return a.raw
+}
+
+// Raw 链上最小单位整数（副本）。
+func (a TokenAmount) Raw() *big.Int { return new(big.Int).Set(a.int()) }
+
+// Decimals 代币精度。
+func (a TokenAmount) Decimals() uint8 { return a.decimals }
+
+// Symbol 币种符号。
+func (a TokenAmount) Symbol() string { return a.symbol }
+
+// IsZero 是否为 0。
+func (a TokenAmount) IsZero() bool { return a.int().Sign() == 0 }
+
+// Decimal 精确的人类可读金额。
+func (a TokenAmount) Decimal() decimal.Decimal {
+	return decimal.NewFromBigInt(a.int(), -int32(a.decimals))
+}
+
+// Round 按 places 位小数和 mode 舍入后的人类可读金额，用于展示。RoundExact 等同于不舍入。
+func (a TokenAmount) Round(places int32, mode RoundingMode) decimal.Decimal {
+	return roundDecimal(a.Decimal(), places, mode)
+}
+
+// String 如 "12.5 USDT"；无符号时只有数字。
+func (a TokenAmount) String() string {
+	if a.symbol == "" {
+		return a.Decimal().String()
+	}
+	return a.Decimal().String() + " " + a.symbol
+}
+
+// SameToken 币种符号与精度是否都相同，只有相同才可以相互运算。
+func (a TokenAmount) SameToken(b TokenAmount) bool {
+	return a.symbol == b.symbol && a.decimals == b.decimals
+}
+
+// Add 相加，币种不同返回 ErrTokenMismatch，超过 uint256 返回 ErrAmountOverflow。
+func (a TokenAmount) Add(b TokenAmount) (TokenAmount, error) {
+	if !a.SameToken(b) {
+		return TokenAmount{}, ErrTokenMismatch
+	}
+	sum := TokenAmount{raw: new(big.Int).Add(a.int(), b.int()), decimals: a.decimals, symbol: a.symbol}
+	if err := sum.check(); err != nil {
+		return TokenAmount{}, err
+	}
+	return sum, nil
+}
+
+// Sub 相减，结果为负返回 ErrAmountNegative（余额不足）。
+func (a TokenAmount) Sub(b TokenAmount) (TokenAmount, error) {
+	if !a.SameToken(b) {
+		return TokenAmount{}, ErrTokenMismatch
+	}
+	diff := TokenAmount{raw: new(big.Int).Sub(a.int(), b.int()), decimals: a.decimals, symbol: a.symbol}
+	if err := diff.check(); err != nil {
+		return TokenAmount{}, err
+	}
+	return diff, nil
+}
+
+// Cmp 比较大小：a<b 返回 -1，相等 0，a>b 返回 1；币种不同返回 ErrTokenMismatch。
+func (a TokenAmount) Cmp(b TokenAmount) (int, error) {
+	if !a.SameToken(b) {
+		return 0, ErrTokenMismatch
+	}
+	return a.int().Cmp(b.int()), nil
+}
+
+// Equal 币种相同且数值相等。
+func (a TokenAmount) Equal(b TokenAmount) bool {
+	c, err := a.Cmp(b)
+	return err == nil && c == 0
+}
+
+// tokenAmountJSON JSON 形式：raw 用字符串避免 JS 精度丢失，amount 仅供阅读。
+type tokenAmountJSON struct {
+	Raw      string `json:"raw"`
+	Amount   string `json:"amount,omitempty"`
+	Decimals uint8  `json:"decimals"`
+	Symbol   string `json:"symbol,omitempty"`
+}
+
+// MarshalJSON 输出 {"raw":"12500000","amount":"12.5","decimals":6,"symbol":"USDT"}。
+func (a TokenAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tokenAmountJSON{
+		Raw:      a.int().String(),
+		Amount:   a.Decimal().String(),
+		Decimals: a.decimals,
+		Symbol:   a.symbol,
+	})
+}
+
+// UnmarshalJSON 以 raw 为准；缺少 raw 时按 amount 精确换算。
+func (a *TokenAmount) UnmarshalJSON(data []byte) error {
+	var v tokenAmountJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var (
+		out TokenAmount
+		err error
+	)
+	if v.Raw != "" {
+		raw, ok := new(big.Int).SetString(v.Raw, 10)
+		if !ok {
+			return fmt.Errorf("num: invalid raw token amount %q", v.Raw)
+		}
+		out, err = NewTokenAmount(raw, v.Decimals, v.Symbol)
+	} else {
+		out, err = ParseTokenAmount(v.Amount, v.Decimals, v.Symbol)
+	}
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+// Value 实现 driver.Valuer，存为链上整数的十进制字符串，列类型建议 NUMERIC(78,0)。
+func (a TokenAmount) Value() (driver.Value, error) {
+	return a.int().String(), nil
+}
+
+// Scan 实现 sql.Scanner，只读入链上整数，精度与符号沿用接收者原有的值：
+//
+//	a := num.ZeroTokenAmount(6, cryptocurrency.USDT)
+//	err := row.Scan(&a)
+func (a *TokenAmount) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		s = "0"
+	case int64:
+		s = fmt.Sprint(v)
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("%w: %T", ErrAmountScanSource, src)
+	}
+	raw, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fmt.Errorf("num: invalid raw token amount %q", s)
+	}
+	out, err := NewTokenAmount(raw, a.decimals, a.symbol)
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+func roundDecimal(d decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	switch mode {
+	case RoundDown:
+		return d.RoundDown(places)
+	case RoundUp:
+		return d.RoundUp(places)
+	case RoundHalfUp:
+		return d.Round(places)
+	case RoundHalfEven:
+		return d.RoundBank(places)
+	default:
+		return d
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -46,6 +47,12 @@ type ShortIdGenerator struct {
 	baseMono time.Time
 	// Startup offset: (wall clock in ms since epoch) - customEpoch
 	baseOffsetMs uint64
+
+	// High-throughput mode (see NewFastShortIdGenerator). state packs
+	// (lastTime << sequenceBits | sequence) so consecutive values are consecutive IDs.
+	fast       bool
+	state      atomic.Uint64
+	maxDriftMs uint64
 }
 
 // NewShortIdGenerator creates a new generator for the given workerId.
@@ -79,6 +86,34 @@ func NewShortIdGenerator(workerId int64) (*ShortIdGenerator, error) {
 	}, nil
 }
 
+// NewFastShortIdGenerator creates a lock-free generator for the given workerId.
+//
+// NextID and BatchNext advance a packed (time, sequence) word with a single CAS
+// instead of taking the mutex. When the 4-bit sequence of the current millisecond
+// is exhausted the generator borrows the next millisecond instead of sleeping, as
+// long as the logical clock stays within maxDrift of the monotonic clock; beyond
+// that it waits like the mutex version. maxDrift = 0 never runs ahead of the clock.
+//
+// IDs stay unique and strictly increasing per worker, but their embedded time may
+// lead wall clock by up to maxDrift. Keep maxDrift well below the process restart
+// time (e.g. <= 1s), otherwise a fast restart could reissue borrowed timestamps.
+//
+// Sustained throughput is still bounded by 2^sequenceBits IDs per millisecond per
+// worker; this mode removes lock contention and sleep overshoot, and absorbs bursts
+// of up to maxDrift worth of sequence space without waiting.
+func NewFastShortIdGenerator(workerId int64, maxDrift time.Duration) (*ShortIdGenerator, error) {
+	if maxDrift < 0 {
+		return nil, fmt.Errorf("max drift must not be negative")
+	}
+	g, err := NewShortIdGenerator(workerId)
+	if err != nil {
+		return nil, err
+	}
+	g.fast = true
+	g.maxDriftMs = uint64(maxDrift.Milliseconds())
+	return g, nil
+}
+
 // monoNowMs returns a process-monotonic "milliseconds since customEpoch".
 func (g *ShortIdGenerator) monoNowMs() uint64 {
 	elapsed := time.Since(g.baseMono).Milliseconds()
@@ -91,6 +126,10 @@ func (g *ShortIdGenerator) monoNowMs() uint64 {
 
 // NextID generates one ID. It is strictly monotonic within the process for the same worker.
 func (g *ShortIdGenerator) NextID() (uint64, error) {
+	if g.fast {
+		return g.compose(g.reserve(1)), nil
+	}
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -134,6 +173,20 @@ func (g *ShortIdGenerator) BatchNext(n int) ([]uint64, error) {
 	}
 	out := make([]uint64, n)
 
+	if g.fast {
+		// Reserve in chunks no larger than what the drift window can hold.
+		window := int((g.maxDriftMs + 1) << sequenceBits)
+		for i := 0; i < n; {
+			k := min(n-i, window)
+			first := g.reserve(uint64(k))
+			for j := 0; j < k; j++ {
+				out[i+j] = g.compose(first + uint64(j))
+			}
+			i += k
+		}
+		return out, nil
+	}
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -165,6 +218,35 @@ func (g *ShortIdGenerator) BatchNext(n int) ([]uint64, error) {
 	return out, nil
 }
 
+// reserve atomically claims n consecutive (time, sequence) slots and returns the first.
+func (g *ShortIdGenerator) reserve(n uint64) uint64 {
+	for {
+		old := g.state.Load()
+		now := g.monoNowMs()
+
+		next := old + 1
+		if floor := now << sequenceBits; next < floor {
+			// Clock moved past the last slot: restart at sequence 0 of the current ms
+			next = floor
+		}
+		last := next + n - 1
+		if last>>sequenceBits > now+g.maxDriftMs {
+			// Logical clock would run too far ahead: wait for the clock to catch up
+			runtime.Gosched()
+			time.Sleep(spinSleep)
+			continue
+		}
+		if g.state.CompareAndSwap(old, last) {
+			return next
+		}
+	}
+}
+
+// compose builds an ID from a packed (time, sequence) slot.
+func (g *ShortIdGenerator) compose(slot uint64) uint64 {
+	return (slot>>sequenceBits)<<timestampShift | (g.workerId << workerIdShift) | (slot & maxSequence)
+}
+
 // Decompose splits an ID into (timestampMsSinceCustomEpoch, workerId, sequence).
 func (g *ShortIdGenerator) Decompose(id uint64) (tsMs uint64, workerId uint64, seq uint64) {
 	seq = id & maxSequence
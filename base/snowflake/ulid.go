@@ -0,0 +1,229 @@
+package snowflake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ULID is a 128-bit Universally Unique Lexicographically Sortable Identifier:
+// 48-bit big-endian unix milliseconds followed by 80 bits of randomness,
+// rendered as 26 Crockford base32 characters. Byte order equals sort order.
+type ULID [16]byte
+
+const (
+	ulidEncoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLen      = 26
+	maxUnixMs    = (1 << 48) - 1
+)
+
+var (
+	ErrInvalidULID      = errors.New("invalid ULID")
+	ErrNotFromSnowflake = errors.New("identifier was not derived from a snowflake ID")
+)
+
+// ulidDecoding maps ASCII to Crockford base32 values (case-insensitive, I/L->1, O->0); 0xFF is invalid.
+var ulidDecoding = func() [256]byte {
+	var t [256]byte
+	for i := range t {
+		t[i] = 0xFF
+	}
+	for i := 0; i < len(ulidEncoding); i++ {
+		c := ulidEncoding[i]
+		t[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			t[c+'a'-'A'] = byte(i)
+		}
+	}
+	t['I'], t['i'], t['L'], t['l'] = 1, 1, 1, 1
+	t['O'], t['o'] = 0, 0
+	return t
+}()
+
+// String encodes the ULID as 26 upper-case Crockford base32 characters.
+func (u ULID) String() string {
+	var dst [ulidLen]byte
+	// 128 bits -> 26 chars of 5 bits; the first char only carries 3 bits.
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	for i := ulidLen - 1; i >= 0; i-- {
+		dst[i] = ulidEncoding[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(dst[:])
+}
+
+// ParseULID decodes a 26-character ULID string.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != ulidLen {
+		return u, fmt.Errorf("%w: length %d", ErrInvalidULID, len(s))
+	}
+	// The first character may only carry 3 bits, otherwise the value overflows 128 bits.
+	if v := ulidDecoding[s[0]]; v == 0xFF || v > 7 {
+		return u, fmt.Errorf("%w: %q", ErrInvalidULID, s)
+	}
+	var hi, lo uint64
+	for i := 0; i < ulidLen; i++ {
+		v := ulidDecoding[s[i]]
+		if v == 0xFF {
+			return u, fmt.Errorf("%w: bad character %q", ErrInvalidULID, s[i])
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// MustParseULID is like ParseULID but panics on error.
+func MustParseULID(s string) ULID {
+	u, err := ParseULID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Timestamp returns the embedded unix milliseconds.
+func (u ULID) Timestamp() uint64 {
+	return uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+}
+
+// Time returns the embedded timestamp as time.Time.
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(u.Timestamp()))
+}
+
+// Compare returns -1, 0 or +1, matching both byte and string order.
+func (u ULID) Compare(other ULID) int {
+	return bytes.Compare(u[:], other[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *ULID) UnmarshalText(b []byte) error {
+	parsed, err := ParseULID(string(b))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// ULIDGenerator produces ULIDs. In monotonic mode IDs generated within the
+// same millisecond increment the random part by one, so they are strictly
+// increasing within the process even under high throughput.
+type ULIDGenerator struct {
+	mu        sync.Mutex
+	monotonic bool
+	lastMs    uint64
+	entropy   [10]byte
+}
+
+// NewULIDGenerator creates a generator; monotonic selects the ordering mode.
+func NewULIDGenerator(monotonic bool) *ULIDGenerator {
+	return &ULIDGenerator{monotonic: monotonic}
+}
+
+// New generates a ULID for the current time.
+func (g *ULIDGenerator) New() (ULID, error) {
+	return g.NewAt(time.Now())
+}
+
+// NewAt generates a ULID for t. In monotonic mode a t earlier than the last
+// emitted timestamp is clamped to it; when the random part overflows the
+// timestamp is advanced by one millisecond.
+func (g *ULIDGenerator) NewAt(t time.Time) (ULID, error) {
+	var u ULID
+	ms := t.UnixMilli()
+	if ms < 0 || ms > maxUnixMs {
+		return u, fmt.Errorf("%w: timestamp %d out of range", ErrInvalidULID, ms)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := uint64(ms)
+	if g.monotonic && now <= g.lastMs && g.lastMs != 0 {
+		now = g.lastMs
+		if incrementBytes(g.entropy[:]) {
+			now++
+			if _, err := rand.Read(g.entropy[:]); err != nil {
+				return u, err
+			}
+		}
+	} else if _, err := rand.Read(g.entropy[:]); err != nil {
+		return u, err
+	}
+	g.lastMs = now
+
+	putUint48(u[:6], now)
+	copy(u[6:], g.entropy[:])
+	return u, nil
+}
+
+var defaultULID = NewULIDGenerator(true)
+
+// NewULID generates a ULID from the package-level monotonic generator.
+func NewULID() (ULID, error) {
+	return defaultULID.New()
+}
+
+// ULIDFromSnowflake maps a ShortIdGenerator ID onto a ULID losslessly:
+// timestamp = customEpoch + id's time bits, bytes 6-7 zero, bytes 8-15 the
+// snowflake itself. Ordering of snowflake IDs is preserved.
+func ULIDFromSnowflake(id uint64) ULID {
+	var u ULID
+	putUint48(u[:6], SnowflakeUnixMilli(id))
+	binary.BigEndian.PutUint64(u[8:], id)
+	return u
+}
+
+// SnowflakeFromULID reverses ULIDFromSnowflake.
+func SnowflakeFromULID(u ULID) (uint64, error) {
+	id := binary.BigEndian.Uint64(u[8:])
+	if u[6] != 0 || u[7] != 0 || id>>totalBits != 0 || SnowflakeUnixMilli(id) != u.Timestamp() {
+		return 0, ErrNotFromSnowflake
+	}
+	return id, nil
+}
+
+// SnowflakeUnixMilli returns the unix milliseconds embedded in a ShortIdGenerator ID.
+func SnowflakeUnixMilli(id uint64) uint64 {
+	return (id >> timestampShift) + uint64(customEpoch)
+}
+
+// SnowflakeTime returns the time embedded in a ShortIdGenerator ID.
+func SnowflakeTime(id uint64) time.Time {
+	return time.UnixMilli(int64(SnowflakeUnixMilli(id)))
+}
+
+// putUint48 writes the low 48 bits of v big-endian into b[:6].
+func putUint48(b []byte, v uint64) {
+	_ = b[5]
+	b[0], b[1], b[2] = byte(v>>40), byte(v>>32), byte(v>>24)
+	b[3], b[4], b[5] = byte(v>>16), byte(v>>8), byte(v)
+}
+
+// incrementBytes adds one to a big-endian byte slice and reports overflow.
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
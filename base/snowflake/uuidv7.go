@@ -0,0 +1,160 @@
+package snowflake
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotUUIDv7 is returned when a UUID of another version is passed where v7 is required.
+var ErrNotUUIDv7 = errors.New("not a version 7 UUID")
+
+// UUIDv7Generator produces RFC 9562 version 7 UUIDs (48-bit unix ms + 74 random bits).
+// In monotonic mode the 74-bit random field is treated as a counter within the same
+// millisecond (RFC 9562 §6.2 method 2), so IDs are strictly increasing in the process.
+type UUIDv7Generator struct {
+	mu        sync.Mutex
+	monotonic bool
+	lastMs    uint64
+	// entropy holds the 74 random bits right-aligned; the top 6 bits stay zero.
+	entropy [10]byte
+}
+
+// NewUUIDv7Generator creates a generator; monotonic selects the ordering mode.
+func NewUUIDv7Generator(monotonic bool) *UUIDv7Generator {
+	return &UUIDv7Generator{monotonic: monotonic}
+}
+
+// New generates a UUIDv7 for the current time.
+func (g *UUIDv7Generator) New() (uuid.UUID, error) {
+	return g.NewAt(time.Now())
+}
+
+// NewAt generates a UUIDv7 for t, with the same clamping rules as ULIDGenerator.NewAt.
+func (g *UUIDv7Generator) NewAt(t time.Time) (uuid.UUID, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || ms > maxUnixMs {
+		return uuid.Nil, fmt.Errorf("uuidv7: timestamp %d out of range", ms)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := uint64(ms)
+	if g.monotonic && now <= g.lastMs && g.lastMs != 0 {
+		now = g.lastMs
+		if incrementBytes(g.entropy[:]) || g.entropy[0] > 0x03 {
+			now++
+			if err := g.reseed(); err != nil {
+				return uuid.Nil, err
+			}
+		}
+	} else if err := g.reseed(); err != nil {
+		return uuid.Nil, err
+	}
+	g.lastMs = now
+
+	return packUUIDv7(now, g.entropy), nil
+}
+
+func (g *UUIDv7Generator) reseed() error {
+	if _, err := rand.Read(g.entropy[:]); err != nil {
+		return err
+	}
+	// Keep one spare bit below the 74-bit limit so a fresh seed leaves room to count.
+	g.entropy[0] &= 0x01
+	return nil
+}
+
+// packUUIDv7 lays out ms and the right-aligned 74-bit entropy as
+// unix_ts_ms(48) | ver(4)=7 | rand_a(12) | var(2)=0b10 | rand_b(62).
+func packUUIDv7(ms uint64, entropy [10]byte) uuid.UUID {
+	var u uuid.UUID
+	putUint48(u[:6], ms)
+	hi := uint64(entropy[0])<<8 | uint64(entropy[1]) // top 16 bits of the 80-bit field
+	lo := binary.BigEndian.Uint64(entropy[2:])
+	// 74 bits = hi(10 significant) + lo(64): rand_a = top 12 bits, rand_b = low 62 bits.
+	randA := (hi<<2 | lo>>62) & 0x0FFF
+	randB := lo & (1<<62 - 1)
+	u[6] = 0x70 | byte(randA>>8)
+	u[7] = byte(randA)
+	binary.BigEndian.PutUint64(u[8:], randB|0x8000000000000000)
+	return u
+}
+
+var defaultUUIDv7 = NewUUIDv7Generator(true)
+
+// NewUUIDv7 generates a UUIDv7 from the package-level monotonic generator.
+func NewUUIDv7() (uuid.UUID, error) {
+	return defaultUUIDv7.New()
+}
+
+// UUIDv7UnixMilli returns the unix milliseconds embedded in a UUIDv7.
+func UUIDv7UnixMilli(u uuid.UUID) (uint64, error) {
+	if u.Version() != 7 || u.Variant() != uuid.RFC4122 {
+		return 0, ErrNotUUIDv7
+	}
+	return uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5]), nil
+}
+
+// UUIDv7Time returns the time embedded in a UUIDv7.
+func UUIDv7Time(u uuid.UUID) (time.Time, error) {
+	ms, err := UUIDv7UnixMilli(u)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
+
+// UUIDv7FromSnowflake maps a ShortIdGenerator ID onto a UUIDv7 losslessly:
+// timestamp = customEpoch + id's time bits, rand_a zero, and the 48-bit
+// snowflake in the low 48 bits of rand_b. Ordering of snowflake IDs is preserved.
+func UUIDv7FromSnowflake(id uint64) uuid.UUID {
+	var u uuid.UUID
+	putUint48(u[:6], SnowflakeUnixMilli(id))
+	u[6] = 0x70
+	binary.BigEndian.PutUint64(u[8:], id|0x8000000000000000)
+	return u
+}
+
+// SnowflakeFromUUIDv7 reverses UUIDv7FromSnowflake.
+func SnowflakeFromUUIDv7(u uuid.UUID) (uint64, error) {
+	ms, err := UUIDv7UnixMilli(u)
+	if err != nil {
+		return 0, err
+	}
+	id := binary.BigEndian.Uint64(u[8:]) &^ 0x8000000000000000
+	if u[6] != 0x70 || u[7] != 0 || id>>totalBits != 0 || SnowflakeUnixMilli(id) != ms {
+		return 0, ErrNotFromSnowflake
+	}
+	return id, nil
+}
+
+// ULIDFromUUID reinterprets the 16 bytes of a UUID as a ULID. For UUIDv7 the
+// result carries the same timestamp and sorts in the same order; the
+// conversion is lossless and UUIDFromULID(ULIDFromUUID(u)) == u.
+func ULIDFromUUID(u uuid.UUID) ULID {
+	return ULID(u)
+}
+
+// UUIDFromULID reinterprets a ULID as a raw UUID without touching any bits.
+// Use UUIDv7FromULID if the result must be a valid version 7 UUID.
+func UUIDFromULID(u ULID) uuid.UUID {
+	return uuid.UUID(u)
+}
+
+// UUIDv7FromULID converts a ULID into a valid UUIDv7 with the same timestamp.
+// The version and variant bits overwrite 6 of the ULID's 80 random bits, so
+// the conversion is lossy for arbitrary ULIDs (but not for ULIDs produced by ULIDFromUUID).
+func UUIDv7FromULID(u ULID) uuid.UUID {
+	out := uuid.UUID(u)
+	out[6] = 0x70 | out[6]&0x0F
+	out[8] = 0x80 | out[8]&0x3F
+	return out
+}
@@ -4,6 +4,7 @@ const (
 	// 常见加密货币
 
 	USDT  = "USDT"  // 泰达币（稳定币，锚定美元）
+	USDC  = "USDC"  // USD Coin（稳定币，锚定美元）
 	BTC   = "BTC"   // 比特币
 	ETH   = "ETH"   // 以太坊
 	XRP   = "XRP"   // 瑞波币
@@ -25,10 +26,12 @@ const (
 	EOS   = "EOS"   // 柚子币
 	FIL   = "FIL"   // Filecoin
 	MATIC = "MATIC" // Polygon
+	TON   = "TON"   // Toncoin
 
 	// 区块链网络名称
 
 	BtcNetwork     = "BTC"       // 比特币主网
+	BtcTestNetwork = "BTC-TEST"  // 比特币测试网（testnet / signet）
 	EthNetwork     = "ETH"       // 以太坊主网
 	BscNetwork     = "BSC"       // 币安智能链
 	TronNetwork    = "TRON"      // 波场网络
@@ -40,6 +43,12 @@ const (
 	OpNetwork      = "Optimism"  // Optimism网络
 	HecoNetwork    = "HECO"      // 火币生态链
 	OkcNetwork     = "OKC"       // OKX链
+	TonNetwork     = "TON"       // The Open Network
+	LtcNetwork     = "LTC"       // 莱特币主网
+	DogeNetwork    = "DOGE"      // 狗狗币主网
+	XrpNetwork     = "XRP"       // 瑞波 XRP Ledger
+	CosmosNetwork  = "Cosmos"    // Cosmos Hub
+	BchNetwork     = "BCH"       // 比特币现金主网
 
 	// 代币协议标准
 
@@ -51,6 +60,7 @@ const (
 	TRC10   = "TRC10"   // 波场TRC10代币标准
 	RC20    = "RC20"    // 通用RC20代币标准
 	SPL     = "SPL"     // Solana程序库代币标准
+	Jetton  = "Jetton"  // TON Jetton代币标准
 
 	// 常见代币在不同链上的类型
 
@@ -77,4 +87,10 @@ var ExplorerURLs = map[string]string{
 	OpNetwork:      "https://optimistic.etherscan.io",     // Optimism浏览器
 	HecoNetwork:    "https://hecoinfo.com",                // HECO浏览器
 	OkcNetwork:     "https://www.oklink.com/okc",          // OKC浏览器
+	TonNetwork:     "https://tonviewer.com",               // TON浏览器
+	LtcNetwork:     "https://litecoinspace.org",           // LTC浏览器
+	DogeNetwork:    "https://dogechain.info",              // DOGE浏览器
+	XrpNetwork:     "https://xrpscan.com",                 // XRP浏览器
+	CosmosNetwork:  "https://www.mintscan.io/cosmos",      // Cosmos浏览器
+	BchNetwork:     "https://blockchair.com/bitcoin-cash", // BCH浏览器
 }
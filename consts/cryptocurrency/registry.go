@@ -0,0 +1,170 @@
+package cryptocurrency
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Asset 加密货币信息及其在各网络上的部署。
+type Asset struct {
+	Symbol      string
+	NameZh      string
+	NameEn      string
+	Deployments []Deployment // 按充值网络菜单的展示顺序排列
+}
+
+// Deployment 资产在某个网络上的部署。Contract 为空表示该网络的原生币。
+// 同一资产在不同链上的精度可能不同，例如 USDT 在 TRON / ETH 上为 6 位，在 BSC 上为 18 位。
+type Deployment struct {
+	Network  string // 网络名称常量，如 TronNetwork
+	Standard string // 代币标准，如 TRC20；原生币为空
+	Contract string // 合约地址（TON 为 Jetton master 地址，Solana 为 mint 地址）
+	Decimals int
+}
+
+// Native 是否为网络原生币。
+func (d Deployment) Native() bool {
+	return d.Contract == ""
+}
+
+// Name 按语言返回名称，lang 以 "zh" 开头时返回中文，否则返回英文。
+func (a Asset) Name(lang string) string {
+	if strings.HasPrefix(strings.ToLower(lang), "zh") {
+		return a.NameZh
+	}
+	return a.NameEn
+}
+
+// On 返回资产在 network 上的部署。
+func (a Asset) On(network string) (Deployment, bool) {
+	for _, d := range a.Deployments {
+		if d.Network == network {
+			return d, true
+		}
+	}
+	return Deployment{}, false
+}
+
+// Networks 返回资产支持的网络，顺序同 Deployments。
+func (a Asset) Networks() []string {
+	out := make([]string, len(a.Deployments))
+	for i, d := range a.Deployments {
+		out[i] = d.Network
+	}
+	return out
+}
+
+func native(network string, decimals int) Deployment {
+	return Deployment{Network: network, Decimals: decimals}
+}
+
+var assets = map[string]Asset{
+	USDT: {USDT, "泰达币", "Tether USD", []Deployment{
+		{TronNetwork, TRC20, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 6},
+		{EthNetwork, ERC20, "0xdAC17F958D2ee523a2206206994597C13D831ec7", 6},
+		{BscNetwork, BEP20, "0x55d398326f99059fF775485246999027B3197955", 18},
+		{PolygonNetwork, ERC20, "0xc2132D05D31c914a87C6611C10748AEb04B58e8F", 6},
+		{ArbNetwork, ERC20, "0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9", 6},
+		{OpNetwork, ERC20, "0x94b008aA00579c1307B0EF2c499aD98a8ce58e58", 6},
+		{AvaxNetwork, ERC20, "0x9702230A8Ea53601f5cD2dc00fDBc13d4dF4A8c7", 6},
+		{SolNetwork, SPL, "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB", 6},
+		{TonNetwork, Jetton, "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs", 6},
+	}},
+	USDC: {USDC, "美元币", "USD Coin", []Deployment{
+		{EthNetwork, ERC20, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", 6},
+		{BscNetwork, BEP20, "0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d", 18},
+		{PolygonNetwork, ERC20, "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", 6},
+		{ArbNetwork, ERC20, "0xaf88d065e77c8cC2239327C5EDb3A432268e5831", 6},
+		{OpNetwork, ERC20, "0x0b2C639c533813f4Aa9D7837cAf62653d097Ff85", 6},
+		{AvaxNetwork, ERC20, "0xB97EF9Ef8734C71904D8002F8b6Bc66Dd9c48a6E", 6},
+		{SolNetwork, SPL, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", 6},
+	}},
+	BTC:   {BTC, "比特币", "Bitcoin", []Deployment{native(BtcNetwork, 8)}},
+	ETH:   {ETH, "以太坊", "Ethereum", []Deployment{native(EthNetwork, 18), native(ArbNetwork, 18), native(OpNetwork, 18)}},
+	BNB:   {BNB, "币安币", "BNB", []Deployment{native(BscNetwork, 18)}},
+	TRX:   {TRX, "波场币", "TRON", []Deployment{native(TronNetwork, 6)}},
+	SOL:   {SOL, "Solana", "Solana", []Deployment{native(SolNetwork, 9)}},
+	TON:   {TON, "Toncoin", "Toncoin", []Deployment{native(TonNetwork, 9)}},
+	LTC:   {LTC, "莱特币", "Litecoin", []Deployment{native(LtcNetwork, 8)}},
+	DOGE:  {DOGE, "狗狗币", "Dogecoin", []Deployment{native(DogeNetwork, 8)}},
+	XRP:   {XRP, "瑞波币", "XRP", []Deployment{native(XrpNetwork, 6)}},
+	ATOM:  {ATOM, "宇宙币", "Cosmos", []Deployment{native(CosmosNetwork, 6)}},
+	BCH:   {BCH, "比特币现金", "Bitcoin Cash", []Deployment{native(BchNetwork, 8)}},
+	MATIC: {MATIC, "Polygon", "Polygon", []Deployment{native(PolygonNetwork, 18)}},
+}
+
+// LookupAsset 按符号查询资产，不区分大小写。
+func LookupAsset(symbol string) (Asset, bool) {
+	a, ok := assets[strings.ToUpper(strings.TrimSpace(symbol))]
+	a.Deployments = slices.Clone(a.Deployments)
+	return a, ok
+}
+
+// IsValid 是否为已登记的资产符号。
+func IsValid(symbol string) bool {
+	_, ok := LookupAsset(symbol)
+	return ok
+}
+
+// IsSupported 资产是否可在 network 上充提。
+func IsSupported(symbol, network string) bool {
+	a, ok := LookupAsset(symbol)
+	if !ok {
+		return false
+	}
+	_, ok = a.On(network)
+	return ok
+}
+
+// Decimals 返回资产在 network 上的精度。
+func Decimals(symbol, network string) (int, bool) {
+	a, ok := LookupAsset(symbol)
+	if !ok {
+		return 0, false
+	}
+	d, ok := a.On(network)
+	return d.Decimals, ok
+}
+
+// LookupContract 按网络与合约地址反查资产，用于识别链上收到的代币；EVM 地址不区分大小写。
+func LookupContract(network, contract string) (Asset, Deployment, bool) {
+	if contract == "" {
+		return Asset{}, Deployment{}, false
+	}
+	evm := strings.HasPrefix(contract, "0x") || strings.HasPrefix(contract, "0X")
+	for _, a := range assets {
+		for _, d := range a.Deployments {
+			if d.Network != network || d.Contract == "" {
+				continue
+			}
+			if d.Contract == contract || evm && strings.EqualFold(d.Contract, contract) {
+				a.Deployments = slices.Clone(a.Deployments)
+				return a, d, true
+			}
+		}
+	}
+	return Asset{}, Deployment{}, false
+}
+
+// Assets 返回全部已登记资产（按符号排序）。
+func Assets() []Asset {
+	out := make([]Asset, 0, len(assets))
+	for _, a := range assets {
+		a.Deployments = slices.Clone(a.Deployments)
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Symbol < out[j].Symbol })
+	return out
+}
+
+// AssetsOn 返回可在 network 上充提的资产（按符号排序），用于按网络展示币种列表。
+func AssetsOn(network string) []Asset {
+	var out []Asset
+	for _, a := range Assets() {
+		if _, ok := a.On(network); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
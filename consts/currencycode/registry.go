@@ -0,0 +1,101 @@
+package currencycode
+
+import (
+	"sort"
+	"strings"
+)
+
+// Currency ISO 4217 法币信息。
+type Currency struct {
+	Code       string // 字母代码，如 "CNY"
+	Numeric    int    // 数字代码，如 156
+	MinorUnits int    // 小数位数，如 CNY 为 2、JPY 为 0
+	Symbol     string // 货币符号，如 "¥"、"$"
+	NameZh     string
+	NameEn     string
+}
+
+// Name 按语言返回名称，lang 以 "zh" 开头时返回中文，否则返回英文。
+func (c Currency) Name(lang string) string {
+	if strings.HasPrefix(strings.ToLower(lang), "zh") {
+		return c.NameZh
+	}
+	return c.NameEn
+}
+
+var currencies = map[string]Currency{
+	CNY: {CNY, 156, 2, "¥", "人民币", "Chinese Yuan"},
+	USD: {USD, 840, 2, "$", "美元", "US Dollar"},
+	EUR: {EUR, 978, 2, "€", "欧元", "Euro"},
+	JPY: {JPY, 392, 0, "¥", "日元", "Japanese Yen"},
+	GBP: {GBP, 826, 2, "£", "英镑", "British Pound Sterling"},
+	AUD: {AUD, 36, 2, "A$", "澳大利亚元", "Australian Dollar"},
+	CAD: {CAD, 124, 2, "CA$", "加拿大元", "Canadian Dollar"},
+	CHF: {CHF, 756, 2, "CHF", "瑞士法郎", "Swiss Franc"},
+	HKD: {HKD, 344, 2, "HK$", "港币", "Hong Kong Dollar"},
+	TWD: {TWD, 901, 2, "NT$", "新台币", "Taiwan Dollar"},
+	KRW: {KRW, 410, 0, "₩", "韩元", "Korean Won"},
+	SGD: {SGD, 702, 2, "S$", "新加坡元", "Singapore Dollar"},
+	NZD: {NZD, 554, 2, "NZ$", "新西兰元", "New Zealand Dollar"},
+	INR: {INR, 356, 2, "₹", "印度卢比", "Indian Rupee"},
+	MYR: {MYR, 458, 2, "RM", "马来西亚林吉特", "Malaysian Ringgit"},
+	THB: {THB, 764, 2, "฿", "泰铢", "Thai Baht"},
+	RUB: {RUB, 643, 2, "₽", "俄罗斯卢布", "Russian Ruble"},
+	AED: {AED, 784, 2, "د.إ", "阿联酋迪拉姆", "UAE Dirham"},
+	SAR: {SAR, 682, 2, "﷼", "沙特里亚尔", "Saudi Riyal"},
+	BRL: {BRL, 986, 2, "R$", "巴西雷亚尔", "Brazilian Real"},
+	IDR: {IDR, 360, 2, "Rp", "印尼盾", "Indonesian Rupiah"},
+	MXN: {MXN, 484, 2, "MX$", "墨西哥比索", "Mexican Peso"},
+	PHP: {PHP, 608, 2, "₱", "菲律宾比索", "Philippine Peso"},
+	ZAR: {ZAR, 710, 2, "R", "南非兰特", "South African Rand"},
+	SEK: {SEK, 752, 2, "kr", "瑞典克朗", "Swedish Krona"},
+	NOK: {NOK, 578, 2, "kr", "挪威克朗", "Norwegian Krone"},
+	DKK: {DKK, 208, 2, "kr", "丹麦克朗", "Danish Krone"},
+	PLN: {PLN, 985, 2, "zł", "波兰兹罗提", "Polish Złoty"},
+	TRY: {TRY, 949, 2, "₺", "土耳其里拉", "Turkish Lira"},
+	VND: {VND, 704, 0, "₫", "越南盾", "Vietnamese Dong"},
+}
+
+var byNumeric = func() map[int]Currency {
+	m := make(map[int]Currency, len(currencies))
+	for _, c := range currencies {
+		m[c.Numeric] = c
+	}
+	return m
+}()
+
+// Lookup 按字母代码查询，不区分大小写。
+func Lookup(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// LookupNumeric 按 ISO 4217 数字代码查询，如 156 → CNY。
+func LookupNumeric(numeric int) (Currency, bool) {
+	c, ok := byNumeric[numeric]
+	return c, ok
+}
+
+// IsValid 是否为已登记的法币代码。
+func IsValid(code string) bool {
+	_, ok := Lookup(code)
+	return ok
+}
+
+// MinorUnits 返回小数位数，未登记的代码按 2 位处理。
+func MinorUnits(code string) int {
+	if c, ok := Lookup(code); ok {
+		return c.MinorUnits
+	}
+	return 2
+}
+
+// All 返回全部已登记法币（按代码排序）。
+func All() []Currency {
+	out := make([]Currency, 0, len(currencies))
+	for _, c := range currencies {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
@@ -0,0 +1,140 @@
+package cryptocoin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -----------------------------
+// Bitcoin Cash：CashAddr
+// -----------------------------
+
+const bchPrefix = "bitcoincash"
+
+// cashAddrPolymod CashAddr 使用 40 位 BCH 码，生成多项式与 Bech32 不同。
+func cashAddrPolymod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = (c&0x07ffffffff)<<5 ^ uint64(d)
+		if c0&0x01 != 0 {
+			c ^= 0x98f2bc8e61
+		}
+		if c0&0x02 != 0 {
+			c ^= 0x79b76d99e2
+		}
+		if c0&0x04 != 0 {
+			c ^= 0xf33e5fb3c4
+		}
+		if c0&0x08 != 0 {
+			c ^= 0xae2eabe2a8
+		}
+		if c0&0x10 != 0 {
+			c ^= 0x1e4f43e470
+		}
+	}
+	return c ^ 1
+}
+
+func cashAddrPrefixValues(prefix string) []byte {
+	out := make([]byte, 0, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		out = append(out, prefix[i]&0x1f)
+	}
+	return append(out, 0)
+}
+
+// DecodeBCHAddress 解码 CashAddr（可省略 "bitcoincash:" 前缀，大小写不可混用），
+// 返回类型（BTCP2PKH / BTCP2SH）与 20 字节哈希，以及带前缀的小写规范形式。
+func DecodeBCHAddress(address string) (normalized string, typ BTCAddressType, hash []byte, err error) {
+	a := strings.TrimSpace(address)
+	if a != strings.ToLower(a) && a != strings.ToUpper(a) {
+		return "", "", nil, fmt.Errorf("%w: mixed case", ErrAddressFormat)
+	}
+	a = strings.ToLower(a)
+	prefix, payload := bchPrefix, a
+	if i := strings.IndexByte(a, ':'); i >= 0 {
+		prefix, payload = a[:i], a[i+1:]
+	}
+	if prefix != bchPrefix || len(payload) != 42 {
+		return "", "", nil, fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+
+	values := make([]byte, len(payload))
+	for i := 0; i < len(payload); i++ {
+		d := strings.IndexByte(bech32Charset, payload[i])
+		if d < 0 {
+			return "", "", nil, fmt.Errorf("%w: invalid char %q", ErrAddressFormat, payload[i])
+		}
+		values[i] = byte(d)
+	}
+	if cashAddrPolymod(append(cashAddrPrefixValues(prefix), values...)) != 0 {
+		return "", "", nil, fmt.Errorf("%w: %q", ErrAddressChecksum, address)
+	}
+	data, err := convertBits(values[:len(values)-8], 5, 8, false)
+	if err != nil || len(data) != 21 {
+		return "", "", nil, fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+	// version 字节：高位 type（0=P2KH，1=P2SH），低 3 位 size（0=160 位）
+	switch data[0] {
+	case 0x00:
+		typ = BTCP2PKH
+	case 0x08:
+		typ = BTCP2SH
+	default:
+		return "", "", nil, fmt.Errorf("%w: version 0x%02x", ErrAddressFormat, data[0])
+	}
+	return prefix + ":" + payload, typ, data[1:], nil
+}
+
+// IsValidBCHAddress 校验比特币现金主网 CashAddr 地址。
+// 旧式 Base58 地址与 BTC 无法区分，请先用 BCHFromLegacy 转换。
+func IsValidBCHAddress(address string) bool {
+	_, _, _, err := DecodeBCHAddress(address)
+	return err == nil
+}
+
+// EncodeBCHAddress 把类型与 20 字节哈希编码为带前缀的 CashAddr。
+func EncodeBCHAddress(typ BTCAddressType, hash []byte) (string, error) {
+	var version byte
+	switch typ {
+	case BTCP2PKH:
+	case BTCP2SH:
+		version = 0x08
+	default:
+		return "", fmt.Errorf("%w: unsupported type %s", ErrAddressFormat, typ)
+	}
+	if len(hash) != 20 {
+		return "", fmt.Errorf("%w: hash must be 20 bytes", ErrAddressFormat)
+	}
+	values, err := convertBits(append([]byte{version}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	mod := cashAddrPolymod(append(append(cashAddrPrefixValues(bchPrefix), values...), make([]byte, 8)...))
+	var b strings.Builder
+	b.WriteString(bchPrefix + ":")
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 8; i++ {
+		b.WriteByte(bech32Charset[(mod>>uint(5*(7-i)))&0x1f])
+	}
+	return b.String(), nil
+}
+
+// BCHFromLegacy 把旧式 Base58 地址（1... / 3...）转换为 CashAddr。
+func BCHFromLegacy(legacy string) (string, error) {
+	version, payload, err := base58CheckDecode(strings.TrimSpace(legacy))
+	if err != nil {
+		return "", err
+	}
+	switch version {
+	case 0x00:
+		return EncodeBCHAddress(BTCP2PKH, payload)
+	case 0x05:
+		return EncodeBCHAddress(BTCP2SH, payload)
+	default:
+		return "", fmt.Errorf("%w: version 0x%02x", ErrAddressFormat, version)
+	}
+}
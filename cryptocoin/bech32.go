@@ -0,0 +1,144 @@
+package cryptocoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// bech32Encoding 区分 BIP-0173 Bech32 与 BIP-0350 Bech32m（仅校验和常数不同）。
+type bech32Encoding int
+
+const (
+	encBech32  bech32Encoding = 1
+	encBech32m bech32Encoding = 2
+)
+
+const (
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var errBech32Checksum = errors.New("invalid bech32 checksum")
+
+func bech32Polymod(values []byte) uint32 {
+	var chk uint32 = 1
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	for _, v := range values {
+		b := byte(chk >> 25)
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if ((b >> uint(i)) & 1) == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		ret = append(ret, byte(c>>5))
+	}
+	ret = append(ret, 0)
+	for _, c := range hrp {
+		ret = append(ret, byte(c&31))
+	}
+	return ret
+}
+
+// bech32DecodeAny 解码 Bech32 / Bech32m 字符串，返回 HRP、去掉校验和的 5-bit 数据以及所用编码。
+// maxLen 为整体长度上限（BIP-0173 为 90，Lightning 等场景可放宽）。
+func bech32DecodeAny(bech string, maxLen int) (string, []byte, bech32Encoding, error) {
+	if len(bech) > maxLen {
+		return "", nil, 0, errors.New("invalid bech32 length")
+	}
+	lower, upper := strings.ToLower(bech), strings.ToUpper(bech)
+	if bech != lower && bech != upper {
+		return "", nil, 0, errors.New("mixed case bech32 string")
+	}
+	bech = lower
+	pos := strings.LastIndexByte(bech, '1')
+	if pos < 1 || pos+7 > len(bech) {
+		return "", nil, 0, errors.New("invalid bech32 separator position")
+	}
+	hrp := bech[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, errors.New("invalid bech32 hrp char")
+		}
+	}
+	data := bech[pos+1:]
+
+	values := make([]byte, len(data))
+	for i := 0; i < len(data); i++ {
+		d := strings.IndexByte(bech32Charset, data[i])
+		if d < 0 {
+			return "", nil, 0, errors.New("invalid bech32 char")
+		}
+		values[i] = byte(d)
+	}
+	var enc bech32Encoding
+	switch bech32Polymod(append(bech32HrpExpand(hrp), values...)) {
+	case bech32Const:
+		enc = encBech32
+	case bech32mConst:
+		enc = encBech32m
+	default:
+		return "", nil, 0, errBech32Checksum
+	}
+	return hrp, values[:len(values)-6], enc, nil
+}
+
+// bech32Encode 把 HRP 与 5-bit 数据编码为 Bech32 / Bech32m 字符串（小写）。
+func bech32Encode(hrp string, data []byte, enc bech32Encoding) string {
+	c := uint32(bech32Const)
+	if enc == encBech32m {
+		c = bech32mConst
+	}
+	values := append(bech32HrpExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ c
+
+	var b strings.Builder
+	b.Grow(len(hrp) + 1 + len(data) + 6)
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, d := range data {
+		b.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return b.String()
+}
+
+// convertBits 在不同位宽之间重新分组（BIP-0173 参考实现）。
+// pad=false 时要求剩余位不足 fromBits 且全为 0。
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data range: %d", v)
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
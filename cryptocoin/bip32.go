@@ -0,0 +1,311 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+// -----------------------------
+// BIP-32 扩展密钥（secp256k1）
+// -----------------------------
+
+// HardenedKeyStart 硬化派生的起始索引（2^31），路径中写作 0' / 0h。
+const HardenedKeyStart uint32 = 0x80000000
+
+var (
+	ErrInvalidExtendedKey = errors.New("invalid extended key")
+	ErrInvalidPath        = errors.New("invalid derivation path")
+	// ErrHardenedFromPublic 公钥（xpub）无法派生硬化子密钥。
+	ErrHardenedFromPublic = errors.New("cannot derive hardened child from public key")
+	// ErrInvalidChild 该索引派生出的密钥无效（概率约 2^-127），BIP-32 要求跳过改用下一个索引。
+	ErrInvalidChild = errors.New("derived key is invalid, use next index")
+)
+
+// KeyVersion 扩展密钥序列化时的版本字节，同时约定了该账户应使用的地址类型（SLIP-0132）。
+type KeyVersion struct {
+	Private     uint32
+	Public      uint32
+	Network     BTCNetwork
+	AddressType BTCAddressType // BTCP2SH 表示 P2SH-P2WPKH（BIP-49）
+}
+
+var (
+	VersionXPub = KeyVersion{0x0488ade4, 0x0488b21e, BTCMainnet, BTCP2PKH}  // xprv / xpub，BIP-44
+	VersionYPub = KeyVersion{0x049d7878, 0x049d7cb2, BTCMainnet, BTCP2SH}   // yprv / ypub，BIP-49
+	VersionZPub = KeyVersion{0x04b2430c, 0x04b24746, BTCMainnet, BTCP2WPKH} // zprv / zpub，BIP-84
+	VersionTPub = KeyVersion{0x04358394, 0x043587cf, BTCTestnet, BTCP2PKH}  // tprv / tpub
+	VersionUPub = KeyVersion{0x044a4e28, 0x044a5262, BTCTestnet, BTCP2SH}   // uprv / upub
+	VersionVPub = KeyVersion{0x045f18bc, 0x045f1cf6, BTCTestnet, BTCP2WPKH} // vprv / vpub
+)
+
+var keyVersions = []KeyVersion{VersionXPub, VersionYPub, VersionZPub, VersionTPub, VersionUPub, VersionVPub}
+
+// ExtendedKey BIP-32 扩展私钥或扩展公钥，不可变；派生方法均返回新对象。
+type ExtendedKey struct {
+	version   KeyVersion
+	depth     uint8
+	parentFP  [4]byte
+	childNum  uint32
+	chainCode [32]byte
+	key       []byte // 私钥 32 字节；公钥 33 字节压缩格式
+	private   bool
+}
+
+// NewMasterKey 由种子（通常来自 MnemonicToSeed，16-64 字节）生成主私钥 m，序列化为 xprv。
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("%w: seed must be 16-64 bytes", ErrInvalidExtendedKey)
+	}
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	if !validScalar(sum[:32]) {
+		return nil, ErrInvalidChild
+	}
+	k := &ExtendedKey{version: VersionXPub, key: append([]byte{}, sum[:32]...), private: true}
+	copy(k.chainCode[:], sum[32:])
+	return k, nil
+}
+
+// ParseExtendedKey 解析 xprv/xpub/yprv/ypub/zprv/zpub 及对应测试网格式。
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	raw, err := base58Decode(strings.TrimSpace(s))
+	if err != nil || len(raw) != 82 {
+		return nil, ErrInvalidExtendedKey
+	}
+	payload := raw[:78]
+	h1 := sha256.Sum256(payload)
+	h2 := sha256.Sum256(h1[:])
+	if !bytes.Equal(raw[78:], h2[:4]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidExtendedKey)
+	}
+
+	k := &ExtendedKey{depth: payload[4], childNum: binary.BigEndian.Uint32(payload[9:13])}
+	v := binary.BigEndian.Uint32(payload[:4])
+	found := false
+	for _, kv := range keyVersions {
+		if v == kv.Private || v == kv.Public {
+			k.version, k.private, found = kv, v == kv.Private, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: unknown version 0x%08x", ErrInvalidExtendedKey, v)
+	}
+	copy(k.parentFP[:], payload[5:9])
+	copy(k.chainCode[:], payload[13:45])
+	if k.depth == 0 && (k.parentFP != [4]byte{} || k.childNum != 0) {
+		return nil, fmt.Errorf("%w: master key with parent", ErrInvalidExtendedKey)
+	}
+
+	keyData := payload[45:78]
+	if k.private {
+		if keyData[0] != 0x00 || !validScalar(keyData[1:]) {
+			return nil, fmt.Errorf("%w: bad private key", ErrInvalidExtendedKey)
+		}
+		k.key = append([]byte{}, keyData[1:]...)
+		return k, nil
+	}
+	if _, err := secpParsePubKey(keyData); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExtendedKey, err)
+	}
+	k.key = append([]byte{}, keyData...)
+	return k, nil
+}
+
+// String 序列化为 Base58Check（xprv... / xpub... 等，取决于版本）。
+func (k *ExtendedKey) String() string {
+	buf := make([]byte, 0, 78)
+	v := k.version.Public
+	if k.private {
+		v = k.version.Private
+	}
+	buf = binary.BigEndian.AppendUint32(buf, v)
+	buf = append(buf, k.depth)
+	buf = append(buf, k.parentFP[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, k.childNum)
+	buf = append(buf, k.chainCode[:]...)
+	if k.private {
+		buf = append(buf, 0x00)
+	}
+	buf = append(buf, k.key...)
+	h1 := sha256.Sum256(buf)
+	h2 := sha256.Sum256(h1[:])
+	return base58Encode(append(buf, h2[:4]...))
+}
+
+// WithVersion 以另一种版本字节重新序列化（例如把 xpub 导出为 zpub），密钥本身不变。
+func (k *ExtendedKey) WithVersion(v KeyVersion) *ExtendedKey {
+	c := *k
+	c.version = v
+	return &c
+}
+
+// Version 返回版本信息，可据此选择默认地址类型。
+func (k *ExtendedKey) Version() KeyVersion { return k.version }
+
+// IsPrivate 是否为扩展私钥。
+func (k *ExtendedKey) IsPrivate() bool { return k.private }
+
+// Depth 派生深度，主密钥为 0。
+func (k *ExtendedKey) Depth() uint8 { return k.depth }
+
+// ChildIndex 本密钥在父级下的索引，硬化索引 >= HardenedKeyStart。
+func (k *ExtendedKey) ChildIndex() uint32 { return k.childNum }
+
+// ParentFingerprint 父公钥 HASH160 的前 4 字节。
+func (k *ExtendedKey) ParentFingerprint() uint32 { return binary.BigEndian.Uint32(k.parentFP[:]) }
+
+// Fingerprint 本公钥 HASH160 的前 4 字节，钱包软件用它标识主密钥或账户。
+func (k *ExtendedKey) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(hash160(k.PublicKey())[:4])
+}
+
+// PublicKey 33 字节压缩公钥。
+func (k *ExtendedKey) PublicKey() []byte {
+	if !k.private {
+		return append([]byte{}, k.key...)
+	}
+	return secpPubKeyFromPriv(k.key).SerializeCompressed()
+}
+
+// PrivateKey 32 字节私钥；扩展公钥返回 nil。
+func (k *ExtendedKey) PrivateKey() []byte {
+	if !k.private {
+		return nil
+	}
+	return append([]byte{}, k.key...)
+}
+
+// Neuter 返回对应的扩展公钥（xprv -> xpub），可交给只读（watch-only）服务派生地址。
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	c := *k
+	c.private = false
+	c.key = k.PublicKey()
+	return &c
+}
+
+// Child 派生第 index 个子密钥；index >= HardenedKeyStart 为硬化派生，只能由私钥进行。
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedKeyStart
+	if hardened && !k.private {
+		return nil, ErrHardenedFromPublic
+	}
+
+	pub := k.PublicKey()
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(append(data, 0x00), k.key...)
+	} else {
+		data = append(data, pub...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il, ok := secpScalar(sum[:32])
+	if !ok {
+		return nil, ErrInvalidChild
+	}
+	child := &ExtendedKey{
+		version:  k.version,
+		depth:    k.depth + 1,
+		childNum: index,
+		private:  k.private,
+	}
+	copy(child.parentFP[:], hash160(pub)[:4])
+	copy(child.chainCode[:], sum[32:])
+
+	if k.private {
+		// k_i = IL + k_par (mod n)
+		parent, _ := secpScalar(k.key)
+		ki := il.Add(parent)
+		if ki.IsZero() {
+			return nil, ErrInvalidChild
+		}
+		b := ki.Bytes()
+		child.key = b[:]
+		return child, nil
+	}
+	// K_i = IL·G + K_par
+	parent, err := secpParsePubKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := secpAddScalarBase(il, parent)
+	if !ok {
+		return nil, ErrInvalidChild
+	}
+	child.key = p.SerializeCompressed()
+	return child, nil
+}
+
+// Derive 按路径逐级派生，路径相对于 k：
+//
+//	master.Derive("m/44'/60'/0'/0/5")
+//	accountXPub.Derive("0/5") // 从账户级 xpub 派生第 5 个收款地址
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := k
+	for _, i := range indexes {
+		if cur, err = cur.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return cur, nil
+}
+
+// ParsePath 解析 "m/44'/0'/0'/0/1" 形式的路径，开头的 "m" 可省略，硬化标记支持 ' h H。
+func ParsePath(path string) ([]uint32, error) {
+	p := strings.TrimSpace(path)
+	if p == "m" || p == "M" || p == "" {
+		return nil, nil
+	}
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "m/"), "M/")
+	parts := strings.Split(p, "/")
+	out := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		hardened := false
+		if n := len(part); n > 0 && (part[n-1] == '\'' || part[n-1] == 'h' || part[n-1] == 'H') {
+			hardened, part = true, part[:n-1]
+		}
+		v, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(v) >= HardenedKeyStart {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		if hardened {
+			v += uint64(HardenedKeyStart)
+		}
+		out = append(out, uint32(v))
+	}
+	return out, nil
+}
+
+func validScalar(b []byte) bool {
+	s, ok := secpScalar(b)
+	return ok && !s.IsZero()
+}
+
+// hash160 RIPEMD160(SHA256(b))。
+func hash160(b []byte) []byte {
+	s := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(s[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,127 @@
+package cryptocoin
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// -----------------------------
+// BIP-39 助记词
+// -----------------------------
+
+//go:embed bip39_english.txt
+var bip39English string
+
+var (
+	ErrEntropyLength    = errors.New("entropy must be 128-256 bits in 32-bit steps")
+	ErrMnemonicLength   = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrMnemonicWord     = errors.New("word not in BIP-39 english wordlist")
+	ErrMnemonicChecksum = errors.New("mnemonic checksum mismatch")
+)
+
+var bip39Words = sync.OnceValues(func() ([]string, map[string]int) {
+	words := strings.Fields(bip39English)
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w] = i
+	}
+	return words, index
+})
+
+// NewMnemonic 生成 bits 位熵（128/160/192/224/256）对应的英文助记词（12-24 个单词）。
+func NewMnemonic(bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", ErrEntropyLength
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy 按 BIP-39 把熵编码为助记词：熵 + SHA-256 前 len/32 位校验，每 11 位一个单词。
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	n := len(entropy) * 8
+	if n < 128 || n > 256 || n%32 != 0 {
+		return "", ErrEntropyLength
+	}
+	sum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), sum[0])
+	words, _ := bip39Words()
+	out := make([]string, (n+n/32)/11)
+	for i := range out {
+		out[i] = words[readBits(data, i*11, 11)]
+	}
+	return strings.Join(out, " "), nil
+}
+
+// MnemonicToEntropy 校验助记词并还原熵。单词大小写、多余空白不敏感。
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	fields := strings.Fields(strings.ToLower(norm.NFKD.String(mnemonic)))
+	switch len(fields) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, ErrMnemonicLength
+	}
+	_, index := bip39Words()
+	total := len(fields) * 11
+	data := make([]byte, (total+7)/8)
+	for i, w := range fields {
+		v, ok := index[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMnemonicWord, w)
+		}
+		writeBits(data, i*11, 11, v)
+	}
+	csBits := total / 33
+	entropy := data[:(total-csBits)/8]
+	sum := sha256.Sum256(entropy)
+	if readBits(data, total-csBits, csBits) != int(sum[0]>>(8-csBits)) {
+		return nil, ErrMnemonicChecksum
+	}
+	return append([]byte{}, entropy...), nil
+}
+
+// IsValidMnemonic 单词数、单词表与校验位均正确时返回 true。
+func IsValidMnemonic(mnemonic string) bool {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err == nil
+}
+
+// MnemonicToSeed 由助记词与可选密码（passphrase，即“第 25 个词”）生成 64 字节种子：
+// PBKDF2-HMAC-SHA512，2048 轮，salt = "mnemonic" + passphrase，均先做 NFKD 规范化。
+// 按 BIP-39 规定这里不校验助记词，需要时先调用 MnemonicToEntropy。
+// 仅在启用 FIPS 140 严格模式时返回错误（salt 短于 FIPS 要求）。
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	m := strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	return pbkdf2.Key(sha512.New, m, []byte("mnemonic"+norm.NFKD.String(passphrase)), 2048, 64)
+}
+
+// readBits 从 data 的第 off 位（大端）起读取 n 位。
+func readBits(data []byte, off, n int) int {
+	v := 0
+	for i := off; i < off+n; i++ {
+		v = v<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+	return v
+}
+
+// writeBits 把 v 的低 n 位写入 data 的第 off 位起（大端）。
+func writeBits(data []byte, off, n, v int) {
+	for i := 0; i < n; i++ {
+		if v>>(n-1-i)&1 == 1 {
+			p := off + i
+			data[p/8] |= 1 << (7 - p%8)
+		}
+	}
+}
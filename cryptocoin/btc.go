@@ -0,0 +1,161 @@
+package cryptocoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BTCAddressType 比特币地址（输出脚本）类型。
+type BTCAddressType string
+
+const (
+	BTCP2PKH          BTCAddressType = "P2PKH"  // 1...（Base58Check，version 0x00 / 0x6f）
+	BTCP2SH           BTCAddressType = "P2SH"   // 3...（Base58Check，version 0x05 / 0xc4）
+	BTCP2WPKH         BTCAddressType = "P2WPKH" // bc1q...，witness v0 + 20 字节
+	BTCP2WSH          BTCAddressType = "P2WSH"  // bc1q...，witness v0 + 32 字节
+	BTCP2TR           BTCAddressType = "P2TR"   // bc1p...，witness v1 + 32 字节（Taproot）
+	BTCWitnessUnknown BTCAddressType = "WitnessUnknown"
+)
+
+// BTCNetwork 比特币网络。
+type BTCNetwork string
+
+const (
+	BTCMainnet BTCNetwork = "mainnet"
+	BTCTestnet BTCNetwork = "testnet" // testnet3 / testnet4 / signet 共用 tb 与版本字节
+	BTCRegtest BTCNetwork = "regtest"
+)
+
+// btcParams 各网络的 bech32 HRP 与 Base58 版本字节。
+// regtest 的 Base58 版本字节与 testnet 相同，无法区分，统一识别为 testnet。
+var btcParams = []struct {
+	network BTCNetwork
+	hrp     string
+	p2pkh   byte
+	p2sh    byte
+}{
+	{BTCMainnet, "bc", 0x00, 0x05},
+	{BTCTestnet, "tb", 0x6f, 0xc4},
+	{BTCRegtest, "bcrt", 0x6f, 0xc4},
+}
+
+// BTCAddressInfo DecodeBTCAddress 的解析结果。
+type BTCAddressInfo struct {
+	Address        string         // 规范化地址（bech32 小写）
+	Type           BTCAddressType // 地址类型
+	Network        BTCNetwork     // 所属网络
+	WitnessVersion int            // SegWit 版本 0-16；Base58 地址为 -1
+	Hash           []byte         // P2PKH 公钥哈希 / P2SH 脚本哈希 / witness program
+}
+
+// IsSegWit 是否为 SegWit（bech32/bech32m）地址。
+func (i BTCAddressInfo) IsSegWit() bool { return i.WitnessVersion >= 0 }
+
+// ScriptPubKey 返回该地址对应的锁定脚本。
+func (i BTCAddressInfo) ScriptPubKey() []byte {
+	switch i.Type {
+	case BTCP2PKH:
+		// OP_DUP OP_HASH160 <20> OP_EQUALVERIFY OP_CHECKSIG
+		s := append([]byte{0x76, 0xa9, 0x14}, i.Hash...)
+		return append(s, 0x88, 0xac)
+	case BTCP2SH:
+		// OP_HASH160 <20> OP_EQUAL
+		s := append([]byte{0xa9, 0x14}, i.Hash...)
+		return append(s, 0x87)
+	default:
+		// OP_n <len> <program>
+		op := byte(0x00)
+		if i.WitnessVersion > 0 {
+			op = byte(0x50 + i.WitnessVersion)
+		}
+		return append([]byte{op, byte(len(i.Hash))}, i.Hash...)
+	}
+}
+
+// DecodeBTCAddress 完整解析比特币地址（主网 / testnet / regtest）：
+//   - Base58Check：P2PKH、P2SH
+//   - SegWit（BIP-0173 / BIP-0350）：校验 witness 版本 0-16、program 长度 2-40 字节、
+//     v0 必须为 20 或 32 字节且使用 Bech32，v1+ 必须使用 Bech32m
+//
+// 错误可用 errors.Is 与 ErrAddressFormat / ErrAddressChecksum 比较。
+func DecodeBTCAddress(address string) (BTCAddressInfo, error) {
+	a := strings.TrimSpace(address)
+	if a == "" {
+		return BTCAddressInfo{}, ErrAddressEmpty
+	}
+	lower := strings.ToLower(a)
+	for _, p := range btcParams {
+		// bcrt1 同时以 bc 开头，HRP 需精确匹配分隔符前的部分
+		if pos := strings.LastIndexByte(lower, '1'); pos > 0 && lower[:pos] == p.hrp {
+			return decodeSegWit(a, p.hrp, p.network)
+		}
+	}
+	return decodeBTCBase58(a)
+}
+
+func decodeSegWit(a, hrp string, network BTCNetwork) (BTCAddressInfo, error) {
+	gotHRP, data, enc, err := bech32DecodeAny(a, 90)
+	if err != nil {
+		if errors.Is(err, errBech32Checksum) {
+			return BTCAddressInfo{}, fmt.Errorf("%w: %v", ErrAddressChecksum, err)
+		}
+		return BTCAddressInfo{}, fmt.Errorf("%w: %v", ErrAddressFormat, err)
+	}
+	if gotHRP != hrp || len(data) < 1 {
+		return BTCAddressInfo{}, fmt.Errorf("%w: empty witness data", ErrAddressFormat)
+	}
+	version := int(data[0])
+	if version > 16 {
+		return BTCAddressInfo{}, fmt.Errorf("%w: witness version %d", ErrAddressFormat, version)
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return BTCAddressInfo{}, fmt.Errorf("%w: %v", ErrAddressFormat, err)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return BTCAddressInfo{}, fmt.Errorf("%w: witness program length %d", ErrAddressFormat, len(program))
+	}
+	// BIP-0350：v0 必须 Bech32，v1+ 必须 Bech32m。用错编码等同于校验和错误
+	if version == 0 && enc != encBech32 || version != 0 && enc != encBech32m {
+		return BTCAddressInfo{}, fmt.Errorf("%w: wrong checksum variant for witness v%d", ErrAddressChecksum, version)
+	}
+
+	info := BTCAddressInfo{
+		Address:        strings.ToLower(a),
+		Network:        network,
+		WitnessVersion: version,
+		Hash:           program,
+		Type:           BTCWitnessUnknown,
+	}
+	switch {
+	case version == 0 && len(program) == 20:
+		info.Type = BTCP2WPKH
+	case version == 0 && len(program) == 32:
+		info.Type = BTCP2WSH
+	case version == 0:
+		return BTCAddressInfo{}, fmt.Errorf("%w: witness v0 program must be 20 or 32 bytes, got %d", ErrAddressFormat, len(program))
+	case version == 1 && len(program) == 32:
+		info.Type = BTCP2TR
+	}
+	return info, nil
+}
+
+func decodeBTCBase58(a string) (BTCAddressInfo, error) {
+	version, payload, err := base58CheckDecode(a)
+	if err != nil {
+		return BTCAddressInfo{}, err
+	}
+	info := BTCAddressInfo{Address: a, WitnessVersion: -1, Hash: payload}
+	for _, p := range btcParams[:2] {
+		switch version {
+		case p.p2pkh:
+			info.Type, info.Network = BTCP2PKH, p.network
+			return info, nil
+		case p.p2sh:
+			info.Type, info.Network = BTCP2SH, p.network
+			return info, nil
+		}
+	}
+	return BTCAddressInfo{}, fmt.Errorf("%w: unknown version byte 0x%02x", ErrAddressFormat, version)
+}
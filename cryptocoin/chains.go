@@ -0,0 +1,120 @@
+package cryptocoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// -----------------------------
+// Solana：Base58 编码的 32 字节 ed25519 公钥
+// -----------------------------
+
+// IsValidSolanaAddress Solana 地址为 32 字节公钥的 Base58 编码（32-44 字符）。
+// 不检查是否在曲线上——PDA（程序派生地址）本身就不在曲线上，同样是合法收款地址。
+func IsValidSolanaAddress(address string) bool {
+	a := strings.TrimSpace(address)
+	if len(a) < 32 || len(a) > 44 {
+		return false
+	}
+	raw, err := base58Decode(a)
+	return err == nil && len(raw) == 32
+}
+
+// -----------------------------
+// Litecoin：Base58Check + Bech32(ltc)
+// -----------------------------
+
+// IsValidLTCAddress 校验莱特币主网地址：
+//   - Base58Check：P2PKH(version=0x30, L...)、P2SH(version=0x32, M...)
+//   - SegWit：HRP=ltc（规则同 BTC，v0 Bech32 / v1+ Bech32m）
+//
+// 旧式 P2SH（version=0x05, 3...）与 BTC 无法区分，为避免误转到 BTC 地址，这里不接受。
+func IsValidLTCAddress(address string) bool {
+	_, err := normalizeLTCAddress(address)
+	return err == nil
+}
+
+func normalizeLTCAddress(address string) (string, error) {
+	a := strings.TrimSpace(address)
+	if strings.HasPrefix(strings.ToLower(a), "ltc1") {
+		return decodeSegWitHRP(a, "ltc")
+	}
+	version, _, err := base58CheckDecode(a)
+	if err != nil {
+		return "", err
+	}
+	if version != 0x30 && version != 0x32 {
+		return "", fmt.Errorf("%w: version 0x%02x", ErrAddressFormat, version)
+	}
+	return a, nil
+}
+
+// decodeSegWitHRP 按 BTC 的 SegWit 规则校验任意 HRP 的地址，返回小写形式。
+func decodeSegWitHRP(a, hrp string) (string, error) {
+	info, err := decodeSegWit(a, hrp, "")
+	if err != nil {
+		return "", err
+	}
+	return info.Address, nil
+}
+
+// -----------------------------
+// Dogecoin：Base58Check
+// -----------------------------
+
+// IsValidDOGEAddress 校验狗狗币主网地址：P2PKH(version=0x1e, D...)、P2SH(version=0x16, 9.../A...)。
+func IsValidDOGEAddress(address string) bool {
+	version, _, err := base58CheckDecode(strings.TrimSpace(address))
+	return err == nil && (version == 0x1e || version == 0x16)
+}
+
+// -----------------------------
+// Cosmos SDK：Bech32
+// -----------------------------
+
+// CosmosHRPs 常见 Cosmos SDK 链的地址前缀。
+var CosmosHRPs = map[string]string{
+	"cosmos":   "Cosmos Hub",
+	"osmo":     "Osmosis",
+	"celestia": "Celestia",
+	"inj":      "Injective",
+	"sei":      "Sei",
+	"dydx":     "dYdX",
+	"kava":     "Kava",
+	"terra":    "Terra",
+}
+
+// DecodeCosmosAddress 解码 Cosmos SDK 账户地址，返回 HRP 与 20 字节（普通账户）
+// 或 32 字节（模块 / ICA / 合约账户）的地址字节。只接受 Bech32（非 Bech32m）。
+func DecodeCosmosAddress(address string) (hrp string, data []byte, err error) {
+	hrp, values, enc, err := bech32DecodeAny(strings.TrimSpace(address), 90)
+	if err != nil {
+		if errors.Is(err, errBech32Checksum) {
+			return "", nil, fmt.Errorf("%w: %v", ErrAddressChecksum, err)
+		}
+		return "", nil, fmt.Errorf("%w: %v", ErrAddressFormat, err)
+	}
+	if enc != encBech32 {
+		return "", nil, fmt.Errorf("%w: cosmos addresses use bech32, not bech32m", ErrAddressChecksum)
+	}
+	data, err = convertBits(values, 5, 8, false)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrAddressFormat, err)
+	}
+	if len(data) != 20 && len(data) != 32 {
+		return "", nil, fmt.Errorf("%w: length %d", ErrAddressFormat, len(data))
+	}
+	return hrp, data, nil
+}
+
+// IsValidCosmosAddress 校验指定 HRP 的 Cosmos SDK 地址（如 "cosmos"、"osmo"）。
+func IsValidCosmosAddress(address, hrp string) bool {
+	got, _, err := DecodeCosmosAddress(address)
+	return err == nil && got == hrp
+}
+
+// IsValidATOMAddress 校验 Cosmos Hub（cosmos1...）地址。
+func IsValidATOMAddress(address string) bool {
+	return IsValidCosmosAddress(address, "cosmos")
+}
@@ -0,0 +1,319 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------
+// EVM：小写 <-> EIP-55
+// -----------------------------
+
+// ToEIP55Address 把任意大小写的 EVM 地址转为 EIP-55 校验大小写形式。
+// 混合大小写输入必须本身通过 EIP-55 校验。
+func ToEIP55Address(addr string) (string, error) {
+	a := strings.TrimSpace(addr)
+	if !IsValidEVMAddress(a) {
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, addr)
+	}
+	return eip55Checksum(a[2:]), nil
+}
+
+// ToLowerEVMAddress 把 EVM 地址转为全小写 0x 形式（数据库存储 / 比较用）。
+func ToLowerEVMAddress(addr string) (string, error) {
+	a := strings.TrimSpace(addr)
+	if !IsValidEVMAddress(a) {
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, addr)
+	}
+	return "0x" + strings.ToLower(a[2:]), nil
+}
+
+// -----------------------------
+// TRON：Base58 <-> 0x41 hex <-> EVM 20 字节 hex
+// -----------------------------
+
+const tronVersion = 0x41
+
+// TronToHex 把 Tron Base58 地址转为 0x41 开头的 42 位 hex（TronGrid / 节点 API 使用的格式）。
+func TronToHex(addr string) (string, error) {
+	payload, err := tronPayload(addr)
+	if err != nil {
+		return "", err
+	}
+	return "41" + hex.EncodeToString(payload), nil
+}
+
+// TronToEVM 把 Tron Base58 地址转为去掉 0x41 的 20 字节 EIP-55 地址（合约 ABI 编码时使用）。
+func TronToEVM(addr string) (string, error) {
+	payload, err := tronPayload(addr)
+	if err != nil {
+		return "", err
+	}
+	return eip55Checksum(hex.EncodeToString(payload)), nil
+}
+
+// TronFromHex 把 hex 形式转回 Tron Base58 地址。接受：
+//   - 42 位 hex，以 41 开头（可带 0x）
+//   - 40 位 hex 的 EVM 风格地址（可带 0x，不区分大小写）
+func TronFromHex(h string) (string, error) {
+	s := strings.TrimSpace(h)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, h)
+	}
+	switch {
+	case len(raw) == 21 && raw[0] == tronVersion:
+		raw = raw[1:]
+	case len(raw) == 20:
+	default:
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, h)
+	}
+	return base58CheckEncode(tronVersion, raw), nil
+}
+
+// EVMToTron 把 EVM 地址映射为同一私钥对应的 Tron Base58 地址。
+func EVMToTron(addr string) (string, error) {
+	a := strings.TrimSpace(addr)
+	if !IsValidEVMAddress(a) {
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, addr)
+	}
+	return TronFromHex(a)
+}
+
+func tronPayload(addr string) ([]byte, error) {
+	version, payload, err := base58CheckDecode(strings.TrimSpace(addr))
+	if err != nil {
+		return nil, err
+	}
+	if version != tronVersion {
+		return nil, fmt.Errorf("%w: version 0x%02x", ErrAddressFormat, version)
+	}
+	return payload, nil
+}
+
+// -----------------------------
+// TON：raw <-> friendly
+// -----------------------------
+
+const (
+	tonTagBounceable    = 0x11
+	tonTagNonBounceable = 0x51
+	tonTagTestnet       = 0x80
+)
+
+// TONAddress TON 地址的结构化表示。Bounceable / Testnet 仅 friendly 形式携带，raw 形式解析时为 false。
+type TONAddress struct {
+	Workchain  int
+	Account    [32]byte
+	Bounceable bool
+	Testnet    bool
+}
+
+// decodeTONFriendly 解码 friendly 地址并校验长度、tag 与 CRC16，返回 36 字节原文：
+// [1字节tag][1字节workchain][32字节accountID][2字节CRC16-XMODEM]。
+// 允许 URL 安全与标准两种字母表（无填充），解析与校验共用，保证两边结论一致。
+func decodeTONFriendly(a string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.NewReplacer("+", "-", "/", "_").Replace(a))
+	if err != nil || len(decoded) != 36 {
+		return nil, ErrAddressFormat
+	}
+	if !bytes.Equal(decoded[34:], crc16XModem(decoded[:34])) {
+		return nil, ErrAddressChecksum
+	}
+	if tag := decoded[0] &^ tonTagTestnet; tag != tonTagBounceable && tag != tonTagNonBounceable {
+		return nil, ErrAddressFormat
+	}
+	return decoded, nil
+}
+
+// ParseTONAddress 解析 raw（wc:hex64）或 friendly（Base64 / Base64URL，36 字节）地址。
+func ParseTONAddress(addr string) (TONAddress, error) {
+	a := strings.TrimSpace(addr)
+	if wc, acc, err := ParseTONRaw(a); err == nil {
+		var out TONAddress
+		out.Workchain = wc
+		copy(out.Account[:], acc)
+		return out, nil
+	}
+
+	decoded, err := decodeTONFriendly(a)
+	if err != nil {
+		return TONAddress{}, fmt.Errorf("%w: %q", err, addr)
+	}
+	tag := decoded[0]
+	out := TONAddress{
+		Workchain:  int(int8(decoded[1])),
+		Bounceable: tag&^tonTagTestnet == tonTagBounceable,
+		Testnet:    tag&tonTagTestnet != 0,
+	}
+	copy(out.Account[:], decoded[2:34])
+	return out, nil
+}
+
+// Raw 返回 raw 形式 "<wc>:<hex64>"（小写）。
+func (a TONAddress) Raw() string {
+	return strconv.Itoa(a.Workchain) + ":" + hex.EncodeToString(a.Account[:])
+}
+
+// Friendly 返回 Base64URL 形式的 friendly 地址，按 Bounceable / Testnet 选择 tag
+// （主网 bounceable 以 EQ 开头，non-bounceable 以 UQ 开头）。
+func (a TONAddress) Friendly() string {
+	var buf [36]byte
+	tag := byte(tonTagNonBounceable)
+	if a.Bounceable {
+		tag = tonTagBounceable
+	}
+	if a.Testnet {
+		tag |= tonTagTestnet
+	}
+	buf[0] = tag
+	buf[1] = byte(int8(a.Workchain))
+	copy(buf[2:34], a.Account[:])
+	copy(buf[34:], crc16XModem(buf[:34]))
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+// TONRawToFriendly 把 raw 地址转为 friendly 地址。
+// 钱包地址充值通常用 non-bounceable（UQ...），合约地址用 bounceable（EQ...）。
+func TONRawToFriendly(raw string, bounceable, testnet bool) (string, error) {
+	wc, acc, err := ParseTONRaw(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrAddressFormat, raw)
+	}
+	a := TONAddress{Workchain: wc, Bounceable: bounceable, Testnet: testnet}
+	copy(a.Account[:], acc)
+	return a.Friendly(), nil
+}
+
+// TONFriendlyToRaw 把 friendly（或 raw）地址转为 raw 形式，便于与链上数据比较。
+func TONFriendlyToRaw(addr string) (string, error) {
+	a, err := ParseTONAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	return a.Raw(), nil
+}
+
+// -----------------------------
+// BTC：类型 + 哈希 -> 地址
+// -----------------------------
+
+// EncodeBTCAddress 按网络与类型把哈希 / witness program 编码为地址，
+// 是 DecodeBTCAddress 的逆操作（WitnessUnknown 请用 EncodeSegWitAddress 指定版本）。
+// 哈希长度按类型校验：P2PKH / P2SH / P2WPKH 为 20 字节，P2WSH / P2TR 为 32 字节。
+// regtest 的 P2PKH / P2SH 与 testnet 共用版本字节 0x6f / 0xc4，编码结果再解码会识别为 testnet。
+func EncodeBTCAddress(network BTCNetwork, typ BTCAddressType, hash []byte) (string, error) {
+	for _, p := range btcParams {
+		if p.network != network {
+			continue
+		}
+		switch typ {
+		case BTCP2PKH, BTCP2SH:
+			if len(hash) != 20 {
+				return "", fmt.Errorf("%w: %s hash must be 20 bytes", ErrAddressFormat, typ)
+			}
+			version := p.p2pkh
+			if typ == BTCP2SH {
+				version = p.p2sh
+			}
+			return base58CheckEncode(version, hash), nil
+		case BTCP2WPKH:
+			if len(hash) != 20 {
+				return "", fmt.Errorf("%w: %s hash must be 20 bytes", ErrAddressFormat, typ)
+			}
+			return EncodeSegWitAddress(network, 0, hash)
+		case BTCP2WSH, BTCP2TR:
+			if len(hash) != 32 {
+				return "", fmt.Errorf("%w: %s program must be 32 bytes", ErrAddressFormat, typ)
+			}
+			version := 0
+			if typ == BTCP2TR {
+				version = 1
+			}
+			return EncodeSegWitAddress(network, version, hash)
+		default:
+			return "", fmt.Errorf("%w: unsupported type %s", ErrAddressFormat, typ)
+		}
+	}
+	return "", fmt.Errorf("%w: unknown network %s", ErrAddressFormat, network)
+}
+
+// EncodeSegWitAddress 编码 SegWit 地址：v0 使用 Bech32，v1+ 使用 Bech32m。
+// 编码结果会再经 DecodeBTCAddress 校验，保证生成的地址一定可被本包识别。
+func EncodeSegWitAddress(network BTCNetwork, version int, program []byte) (string, error) {
+	hrp := ""
+	for _, p := range btcParams {
+		if p.network == network {
+			hrp = p.hrp
+		}
+	}
+	if hrp == "" {
+		return "", fmt.Errorf("%w: unknown network %s", ErrAddressFormat, network)
+	}
+	if version < 0 || version > 16 {
+		return "", fmt.Errorf("%w: witness version %d", ErrAddressFormat, version)
+	}
+	data, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	enc := encBech32
+	if version > 0 {
+		enc = encBech32m
+	}
+	addr := bech32Encode(hrp, append([]byte{byte(version)}, data...), enc)
+	if _, err := DecodeBTCAddress(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// -----------------------------
+// Base58 编码
+// -----------------------------
+
+func base58Encode(input []byte) string {
+	return base58EncodeWith(input, b58Alphabet)
+}
+
+// base58EncodeWith 用指定字母表编码。
+func base58EncodeWith(input []byte, alphabet []byte) string {
+	zeros := 0
+	for zeros < len(input) && input[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(input)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	out := make([]byte, 0, len(input)*138/100+1)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// base58CheckEncode 编码 version + payload + sha256d 前 4 字节。
+func base58CheckEncode(version byte, payload []byte) string {
+	buf := make([]byte, 0, 1+len(payload)+4)
+	buf = append(buf, version)
+	buf = append(buf, payload...)
+	h1 := sha256.Sum256(buf)
+	h2 := sha256.Sum256(h1[:])
+	return base58Encode(append(buf, h2[:4]...))
+}
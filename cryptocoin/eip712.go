@@ -0,0 +1,329 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// -----------------------------
+// EIP-712 结构化数据签名（eth_signTypedData_v4）
+// -----------------------------
+
+var ErrTypedData = errors.New("invalid EIP-712 typed data")
+
+// TypedDataField 结构体成员定义。
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData eth_signTypedData_v4 的 JSON 结构，前端把同一份 JSON 交给钱包签名、再提交给服务端验证。
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]any              `json:"domain"`
+	Message     map[string]any              `json:"message"`
+}
+
+// eip712DomainFields types 中未声明 EIP712Domain 时，按域字段推断的规范顺序。
+var eip712DomainFields = []TypedDataField{
+	{"name", "string"},
+	{"version", "string"},
+	{"chainId", "uint256"},
+	{"verifyingContract", "address"},
+	{"salt", "bytes32"},
+}
+
+// ParseTypedData 解析 typed data JSON，数字按 json.Number 保留，避免 uint256 精度丢失。
+func ParseTypedData(data []byte) (TypedData, error) {
+	var td TypedData
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&td); err != nil {
+		return TypedData{}, fmt.Errorf("%w: %v", ErrTypedData, err)
+	}
+	return td, nil
+}
+
+// Hash 待签名摘要：Keccak256(0x19 0x01 || hashStruct(EIP712Domain) || hashStruct(primaryType))。
+func (td TypedData) Hash() ([]byte, error) {
+	types := td.Types
+	if _, ok := types["EIP712Domain"]; !ok {
+		var fields []TypedDataField
+		for _, f := range eip712DomainFields {
+			if _, ok := td.Domain[f.Name]; ok {
+				fields = append(fields, f)
+			}
+		}
+		types = make(map[string][]TypedDataField, len(td.Types)+1)
+		for k, v := range td.Types {
+			types[k] = v
+		}
+		types["EIP712Domain"] = fields
+		td.Types = types
+	}
+	domain, err := td.HashStruct("EIP712Domain", td.Domain)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256([]byte{0x19, 0x01}, domain, msg), nil
+}
+
+// HashStruct Keccak256(typeHash || encodeData(data))。
+func (td TypedData) HashStruct(typ string, data map[string]any) ([]byte, error) {
+	fields, ok := td.Types[typ]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrTypedData, typ)
+	}
+	enc, err := td.EncodeType(typ)
+	if err != nil {
+		return nil, err
+	}
+	buf := keccak256([]byte(enc))
+	for _, f := range fields {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.%s missing", ErrTypedData, typ, f.Name)
+		}
+		word, err := td.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s.%s: %v", ErrTypedData, typ, f.Name, err)
+		}
+		buf = append(buf, word...)
+	}
+	return keccak256(buf), nil
+}
+
+// EncodeType 类型签名，如 "Mail(Person from,Person to,string contents)Person(string name,address wallet)"：
+// 主类型在前，引用到的其他结构体按名称排序追加。
+func (td TypedData) EncodeType(typ string) (string, error) {
+	deps := map[string]bool{}
+	if err := td.collectDeps(typ, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typ)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{typ}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type + " " + f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+func (td TypedData) collectDeps(typ string, seen map[string]bool) error {
+	if seen[typ] {
+		return nil
+	}
+	fields, ok := td.Types[typ]
+	if !ok {
+		return fmt.Errorf("%w: unknown type %q", ErrTypedData, typ)
+	}
+	seen[typ] = true
+	for _, f := range fields {
+		base := f.Type
+		if i := strings.IndexByte(base, '['); i >= 0 {
+			base = base[:i]
+		}
+		if _, ok := td.Types[base]; ok {
+			if err := td.collectDeps(base, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeValue 按类型把值编码为 32 字节；动态类型与数组取 Keccak256，结构体取 hashStruct。
+func (td TypedData) encodeValue(typ string, v any) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndexByte(typ, '[')
+		items, ok := v.([]any)
+		if i < 0 || !ok {
+			return nil, fmt.Errorf("expected array for %s", typ)
+		}
+		if n := typ[i+1 : len(typ)-1]; n != "" && n != strconv.Itoa(len(items)) {
+			return nil, fmt.Errorf("%s expects %s items, got %d", typ, n, len(items))
+		}
+		var buf []byte
+		for _, item := range items {
+			word, err := td.encodeValue(typ[:i], item)
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, word...)
+		}
+		return keccak256(buf), nil
+	}
+	if _, ok := td.Types[typ]; ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s", typ)
+		}
+		return td.HashStruct(typ, m)
+	}
+
+	word := make([]byte, 32)
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("expected string")
+		}
+		return keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := typedDataBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return keccak256(b), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("expected bool")
+		}
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+	case typ == "address":
+		// 钱包传入的地址大小写不一定符合 EIP-55，这里只检查格式
+		s, ok := v.(string)
+		if !ok || len(s) != 42 || !strings.HasPrefix(strings.ToLower(s), "0x") || !isHexString(s[2:]) {
+			return nil, fmt.Errorf("invalid address %v", v)
+		}
+		b, _ := hex.DecodeString(s[2:])
+		copy(word[12:], b)
+		return word, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[5:])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		b, err := typedDataBytes(v)
+		if err != nil || len(b) != n {
+			return nil, fmt.Errorf("expected %d bytes", n)
+		}
+		copy(word, b)
+		return word, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := typ[0] == 'i'
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		n, err := typedDataInt(v)
+		if err != nil {
+			return nil, err
+		}
+		if !intFits(n, bits, signed) {
+			return nil, fmt.Errorf("%s out of range for %s", n, typ)
+		}
+		if n.Sign() < 0 {
+			// 二进制补码
+			n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return n.FillBytes(word), nil
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+}
+
+func intFits(n *big.Int, bits int, signed bool) bool {
+	if !signed {
+		return n.Sign() >= 0 && n.BitLen() <= bits
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	return n.Cmp(new(big.Int).Neg(limit)) >= 0 && n.Cmp(limit) < 0
+}
+
+// typedDataInt 数值可以是 JSON 数字、十进制字符串或 0x 十六进制字符串。
+func typedDataInt(v any) (*big.Int, error) {
+	var s string
+	switch x := v.(type) {
+	case json.Number:
+		s = x.String()
+	case string:
+		s = x
+	case float64:
+		if x != float64(int64(x)) {
+			return nil, fmt.Errorf("non-integer %v", x)
+		}
+		return big.NewInt(int64(x)), nil
+	case int:
+		return big.NewInt(int64(x)), nil
+	case int64:
+		return big.NewInt(x), nil
+	case uint64:
+		return new(big.Int).SetUint64(x), nil
+	case *big.Int:
+		return new(big.Int).Set(x), nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", v)
+	}
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+func typedDataBytes(v any) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		if !strings.HasPrefix(x, "0x") && !strings.HasPrefix(x, "0X") {
+			return nil, errors.New("bytes must be 0x-prefixed hex")
+		}
+		return hex.DecodeString(x[2:])
+	default:
+		return nil, fmt.Errorf("expected bytes, got %T", v)
+	}
+}
+
+// RecoverTypedData 从 eth_signTypedData_v4 签名恢复签名地址（EIP-55）。
+func RecoverTypedData(td TypedData, sigHex string) (string, error) {
+	h, err := td.Hash()
+	if err != nil {
+		return "", err
+	}
+	return recoverEVM(h, sigHex)
+}
+
+// VerifyTypedData 校验 EIP-712 签名是否出自 address。
+func VerifyTypedData(address string, td TypedData, sigHex string) error {
+	h, err := td.Hash()
+	if err != nil {
+		return err
+	}
+	return verifyEVM(address, h, sigHex)
+}
@@ -0,0 +1,127 @@
+package cryptocoin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// -----------------------------
+// BIP-44 路径与地址
+// -----------------------------
+//
+// 充值地址的常见做法：冷端保存助记词，只把账户级扩展公钥交给充值服务，
+// 充值服务按用户序号派生地址（watch-only），私钥不落在线上机器：
+//
+//	seed, _ := cryptocoin.MnemonicToSeed(mnemonic, "")
+//	master, _ := cryptocoin.NewMasterKey(seed)
+//	acct, _ := master.Derive(cryptocoin.AccountPath(cryptocoin.PurposeBIP44, cryptocoin.CoinTypeETH, 0))
+//	xpub := acct.Neuter().String() // 交给充值服务
+//
+//	// 充值服务
+//	acct, _ := cryptocoin.ParseExtendedKey(xpub)
+//	k, _ := acct.Derive(fmt.Sprintf("0/%d", userSeq))
+//	addr := k.EVMAddress()
+
+// BIP-43 purpose，决定 BTC 地址类型。
+const (
+	PurposeBIP44 uint32 = 44 // P2PKH；EVM / Tron 也用 44
+	PurposeBIP49 uint32 = 49 // P2SH-P2WPKH
+	PurposeBIP84 uint32 = 84 // P2WPKH
+	PurposeBIP86 uint32 = 86 // P2TR（单密钥 key path）
+)
+
+// SLIP-0044 币种编号。
+const (
+	CoinTypeBTC     uint32 = 0
+	CoinTypeTestnet uint32 = 1 // 所有测试网共用
+	CoinTypeETH     uint32 = 60
+	CoinTypeTRX     uint32 = 195
+	CoinTypeTON     uint32 = 607
+)
+
+// AccountPath 账户级路径 m/purpose'/coin'/account'，在这一级导出 xpub。
+func AccountPath(purpose, coinType, account uint32) string {
+	return fmt.Sprintf("m/%d'/%d'/%d'", purpose, coinType, account)
+}
+
+// AddressPath 地址级路径 m/purpose'/coin'/account'/change/index，change 0 为收款、1 为找零。
+func AddressPath(purpose, coinType, account, change, index uint32) string {
+	return fmt.Sprintf("%s/%d/%d", AccountPath(purpose, coinType, account), change, index)
+}
+
+// EVMAddress 本密钥对应的 EVM 地址（EIP-55 大小写）：Keccak256(未压缩公钥 X||Y) 的后 20 字节。
+func (k *ExtendedKey) EVMAddress() string {
+	return eip55Checksum(hex.EncodeToString(k.evmHash()))
+}
+
+// TronAddress 本密钥对应的 Tron 地址：与 EVM 相同的 20 字节，前缀 0x41 后 Base58Check。
+func (k *ExtendedKey) TronAddress() string {
+	return base58CheckEncode(0x41, k.evmHash())
+}
+
+func (k *ExtendedKey) evmHash() []byte {
+	p, _ := secpParsePubKey(k.PublicKey())
+	return pubKeyToEVMHash(p)
+}
+
+// BTCAddress 本密钥对应的单签比特币地址：
+//   - BTCP2PKH：HASH160(公钥)
+//   - BTCP2SH：P2SH-P2WPKH（BIP-49），单密钥 P2SH 只有这一种常用形式
+//   - BTCP2WPKH：witness v0 + HASH160(公钥)（BIP-84）
+//   - BTCP2TR：BIP-86 key path，输出公钥为内部公钥按 TapTweak 调整后的 X 坐标
+//
+// 扩展密钥的版本（xpub/ypub/zpub）记录在 Version() 中，可据此选择 typ。
+func (k *ExtendedKey) BTCAddress(network BTCNetwork, typ BTCAddressType) (string, error) {
+	pub := k.PublicKey()
+	switch typ {
+	case BTCP2PKH, BTCP2WPKH:
+		return EncodeBTCAddress(network, typ, hash160(pub))
+	case BTCP2SH:
+		redeem := append([]byte{0x00, 0x14}, hash160(pub)...)
+		return EncodeBTCAddress(network, BTCP2SH, hash160(redeem))
+	case BTCP2TR:
+		p, err := secpParsePubKey(pub)
+		if err != nil {
+			return "", err
+		}
+		out, err := taprootOutputKey(p)
+		if err != nil {
+			return "", err
+		}
+		return EncodeBTCAddress(network, BTCP2TR, out)
+	default:
+		return "", fmt.Errorf("%w: unsupported address type %s", ErrAddressFormat, typ)
+	}
+}
+
+// taprootOutputKey BIP-341/86：Q = lift_x(P) + int(hash_TapTweak(x(P)))·G，返回 x(Q)。
+func taprootOutputKey(p *secp256k1.PublicKey) ([]byte, error) {
+	// lift_x：取 Y 为偶数的点
+	x := p.SerializeCompressed()[1:]
+	even, err := secpParsePubKey(append([]byte{0x02}, x...))
+	if err != nil {
+		return nil, err
+	}
+	t, ok := secpScalar(taggedHash("TapTweak", x))
+	if !ok {
+		return nil, ErrInvalidChild
+	}
+	q, ok := secpAddScalarBase(t, even)
+	if !ok {
+		return nil, ErrInvalidChild
+	}
+	return q.SerializeCompressed()[1:], nil
+}
+
+// taggedHash BIP-340：SHA256(SHA256(tag) || SHA256(tag) || msg)。
+func taggedHash(tag string, msg []byte) []byte {
+	th := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(th[:])
+	h.Write(th[:])
+	h.Write(msg)
+	return h.Sum(nil)
+}
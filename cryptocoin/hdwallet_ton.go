@@ -0,0 +1,213 @@
+package cryptocoin
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// -----------------------------
+// TON：SLIP-0010 ed25519 派生 + 钱包合约地址
+// -----------------------------
+//
+// TON 使用 ed25519，SLIP-0010 只定义了硬化派生，因此无法像 xpub 那样只读派生：
+// 每个 TON 地址都需要种子参与。交易所常见做法是单一 TON 充值地址 + 用户备注（comment）。
+// 与 Trust Wallet / Ledger 兼容的路径为 AccountPath(PurposeBIP44, CoinTypeTON, account)。
+
+var (
+	ErrHardenedOnly = errors.New("ed25519 derivation supports hardened indexes only")
+	ErrInvalidBOC   = errors.New("invalid TON bag of cells")
+)
+
+// DeriveEd25519Key 按 SLIP-0010 由种子派生 ed25519 私钥，路径中每一级都必须是硬化索引。
+func DeriveEd25519Key(seed []byte, path string) (ed25519.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	for _, i := range indexes {
+		if i < HardenedKeyStart {
+			return nil, fmt.Errorf("%w: %q", ErrHardenedOnly, path)
+		}
+		data := make([]byte, 0, 37)
+		data = append(append(data, 0x00), sum[:32]...)
+		data = binary.BigEndian.AppendUint32(data, i)
+		mac = hmac.New(sha512.New, sum[32:])
+		mac.Write(data)
+		sum = mac.Sum(nil)
+	}
+	return ed25519.NewKeyFromSeed(sum[:32]), nil
+}
+
+// TONWalletV4R2CodeHash 官方 wallet v4r2 合约代码 cell 的哈希（hex），
+// 可与 ParseTONWalletCode 的结果比对，确认传入的代码无误。
+const TONWalletV4R2CodeHash = "feb5ff6820e2ff0d9483e7e0d62c817d846789fb4ae580c878866d959dabd5c0"
+
+// TONWalletDefaultID wallet v3/v4 在 workchain 0 上的默认 subwallet_id。
+const TONWalletDefaultID uint32 = 698983191
+
+// TONWalletCode 钱包合约代码 cell 的表示哈希与深度，计算 StateInit（即地址）时需要。
+// 不同钱包版本代码不同，从所用钱包 SDK 取得代码 BOC 后用 ParseTONWalletCode 得到。
+type TONWalletCode struct {
+	Hash  [32]byte
+	Depth uint16
+}
+
+// ParseTONWalletCode 解析 BOC（bag of cells）并计算根 cell 的哈希与深度。
+// 仅支持普通 cell（钱包代码不含 exotic cell）。
+func ParseTONWalletCode(boc []byte) (TONWalletCode, error) {
+	cells, root, err := parseBOC(boc)
+	if err != nil {
+		return TONWalletCode{}, err
+	}
+	return TONWalletCode{Hash: cells[root].hash, Depth: cells[root].depth}, nil
+}
+
+// tonCell BOC 中的一个普通 cell，hash / depth 在解析时自底向上算好。
+type tonCell struct {
+	d2    byte
+	data  []byte
+	refs  []int
+	hash  [32]byte
+	depth uint16
+}
+
+// parseBOC 解析 BOC，返回全部 cell 与第一个根的下标。
+func parseBOC(boc []byte) ([]tonCell, int, error) {
+	fail := func(msg string) ([]tonCell, int, error) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidBOC, msg)
+	}
+	if len(boc) < 6 || binary.BigEndian.Uint32(boc) != 0xb5ee9c72 {
+		return fail("bad magic")
+	}
+	flags, offBytes := boc[4], int(boc[5])
+	size := int(flags & 7)
+	if size == 0 || size > 4 || offBytes == 0 || offBytes > 8 {
+		return fail("bad header")
+	}
+	if flags&0x40 != 0 {
+		body := boc[:len(boc)-4]
+		if crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli)) != binary.LittleEndian.Uint32(boc[len(boc)-4:]) {
+			return fail("crc32c mismatch")
+		}
+		boc = body
+	}
+
+	p := 6
+	read := func(n int) (int, bool) {
+		if p+n > len(boc) {
+			return 0, false
+		}
+		v := 0
+		for _, b := range boc[p : p+n] {
+			v = v<<8 | int(b)
+		}
+		p += n
+		return v, true
+	}
+	count, ok1 := read(size)
+	roots, ok2 := read(size)
+	_, ok3 := read(size) // absent
+	_, ok4 := read(offBytes)
+	if !ok1 || !ok2 || !ok3 || !ok4 || roots < 1 || count < 1 {
+		return fail("truncated header")
+	}
+	// 每个 cell 至少 2 字节描述符，先按剩余长度限制数量，避免伪造的 count 触发超大分配
+	if count > (len(boc)-p)/2 || roots > count {
+		return fail("cell count exceeds payload")
+	}
+	root, ok := read(size)
+	if !ok || root >= count {
+		return fail("bad root index")
+	}
+	p += (roots - 1) * size
+	if flags&0x80 != 0 {
+		p += count * offBytes
+	}
+
+	cells := make([]tonCell, count)
+	for i := range cells {
+		if p+2 > len(boc) {
+			return fail("truncated cell")
+		}
+		d1, d2 := boc[p], boc[p+1]
+		p += 2
+		if d1&0xf8 != 0 || d1&7 > 4 {
+			return fail("unsupported cell type")
+		}
+		n := (int(d2) + 1) / 2
+		if p+n > len(boc) {
+			return fail("truncated cell")
+		}
+		c := tonCell{d2: d2, data: boc[p : p+n]}
+		p += n
+		for r := 0; r < int(d1&7); r++ {
+			ref, ok := read(size)
+			if !ok || ref <= i || ref >= count {
+				return fail("bad reference")
+			}
+			c.refs = append(c.refs, ref)
+		}
+		cells[i] = c
+	}
+
+	// 引用只指向后面的 cell，倒序即可自底向上计算
+	for i := count - 1; i >= 0; i-- {
+		c := &cells[i]
+		refs := make([]tonCellRef, len(c.refs))
+		for j, r := range c.refs {
+			refs[j] = tonCellRef{cells[r].hash, cells[r].depth}
+		}
+		c.hash, c.depth = tonCellHash(c.d2, c.data, refs)
+	}
+	return cells, root, nil
+}
+
+// TONWalletV4Address 计算 wallet v4 合约（seqno=0、无插件）的地址：
+// workchain 0，账户 = StateInit{code, data} 的 cell 哈希。返回 non-bounceable 形式，钱包收款通常如此展示。
+func TONWalletV4Address(code TONWalletCode, pub ed25519.PublicKey, walletID uint32) (TONAddress, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return TONAddress{}, fmt.Errorf("%w: public key must be %d bytes", ErrAddressFormat, ed25519.PublicKeySize)
+	}
+	// data: seqno:uint32 wallet_id:uint32 public_key:bits256 plugins:(HashmapE 8 ..)=0，共 321 位
+	data := make([]byte, 0, 41)
+	data = binary.BigEndian.AppendUint32(data, 0)
+	data = binary.BigEndian.AppendUint32(data, walletID)
+	data = append(data, pub...)
+	data = append(data, 0x40) // 空插件字典位 0 + 补齐标记位 1
+	dataHash, dataDepth := tonCellHash(81, data, nil)
+
+	// StateInit: split_depth:0 special:0 code:1 data:1 library:0 -> 00110 + 补齐位
+	h, _ := tonCellHash(1, []byte{0x34}, []tonCellRef{{code.Hash, code.Depth}, {dataHash, dataDepth}})
+	return TONAddress{Workchain: 0, Account: h}, nil
+}
+
+type tonCellRef struct {
+	hash  [32]byte
+	depth uint16
+}
+
+// tonCellHash 普通 cell 的表示哈希：SHA256(d1 d2 data depth(refs)... hash(refs)...)，
+// d2 = ceil(bits/8) + floor(bits/8)，不足整字节的 data 已含补齐标记位。
+func tonCellHash(d2 byte, data []byte, refs []tonCellRef) ([32]byte, uint16) {
+	buf := make([]byte, 0, 2+len(data)+len(refs)*34)
+	buf = append(buf, byte(len(refs)), d2)
+	buf = append(buf, data...)
+	var depth uint16
+	for _, r := range refs {
+		buf = binary.BigEndian.AppendUint16(buf, r.depth)
+		depth = max(depth, r.depth+1)
+	}
+	for _, r := range refs {
+		buf = append(buf, r.hash[:]...)
+	}
+	return sha256.Sum256(buf), depth
+}
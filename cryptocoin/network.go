@@ -0,0 +1,342 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+)
+
+// Family 地址格式族。同一族内的网络共用同一种地址格式（例如所有 EVM 链）。
+type Family string
+
+const (
+	FamilyEVM  Family = "EVM"
+	FamilyTron Family = "TRON"
+	FamilyBTC  Family = "BTC"
+	FamilyTON  Family = "TON"
+	FamilySOL  Family = "SOL"
+	FamilyLTC  Family = "LTC"
+	FamilyDOGE Family = "DOGE"
+	FamilyXRP  Family = "XRP"
+	FamilyATOM Family = "ATOM"
+	FamilyBCH  Family = "BCH"
+)
+
+// Network 区块链网络描述。ID 复用 consts/cryptocurrency 中的网络名称常量。
+type Network struct {
+	ID      string   // 网络标识，如 cryptocurrency.BscNetwork
+	Name    string   // 展示名
+	Family  Family   // 地址格式族
+	ChainID uint64   // EVM 链 ID，非 EVM 为 0
+	Testnet bool     // 是否测试网
+	Token   AddrType // 该网络上的代币标准（ERC20 / BEP20 / TRC20 ...），仅用于展示
+}
+
+// -----------------------------
+// 校验失败原因
+// -----------------------------
+
+var (
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	ErrAddressEmpty        = errors.New("address is empty")
+	ErrAddressFormat       = errors.New("address format is invalid")
+	ErrAddressChecksum     = errors.New("address checksum mismatch")
+	ErrAddressTestnet      = errors.New("testnet address on mainnet network")
+	ErrAddressMainnet      = errors.New("mainnet address on testnet network")
+	ErrAddressOtherNetwork = errors.New("address belongs to another network")
+)
+
+// AddressError ValidateAddress 的失败结果。Reasons 为上面的哨兵错误，可用 errors.Is 判断；
+// Candidates 为该地址实际可通过校验的网络 ID，便于提示用户「这是 TRON 地址，你选的是 ETH」。
+type AddressError struct {
+	Network    string
+	Address    string
+	Reasons    []error
+	Candidates []string
+}
+
+func (e *AddressError) Error() string {
+	msgs := make([]string, len(e.Reasons))
+	for i, r := range e.Reasons {
+		msgs[i] = r.Error()
+	}
+	s := fmt.Sprintf("invalid %s address %q: %s", e.Network, e.Address, strings.Join(msgs, "; "))
+	if len(e.Candidates) > 0 {
+		s += " (valid on " + strings.Join(e.Candidates, ", ") + ")"
+	}
+	return s
+}
+
+// Unwrap 支持 errors.Is(err, ErrAddressChecksum) 等判断。
+func (e *AddressError) Unwrap() []error { return e.Reasons }
+
+// -----------------------------
+// 网络注册表
+// -----------------------------
+
+var (
+	networksMu sync.RWMutex
+	networks   = map[string]Network{}
+)
+
+func init() {
+	for _, n := range []Network{
+		{ID: cryptocurrency.EthNetwork, Name: "Ethereum", Family: FamilyEVM, ChainID: 1, Token: AddrERC20},
+		{ID: cryptocurrency.BscNetwork, Name: "BNB Smart Chain", Family: FamilyEVM, ChainID: 56, Token: AddrBEP20},
+		{ID: cryptocurrency.PolygonNetwork, Name: "Polygon PoS", Family: FamilyEVM, ChainID: 137, Token: AddrERC20},
+		{ID: cryptocurrency.ArbNetwork, Name: "Arbitrum One", Family: FamilyEVM, ChainID: 42161, Token: AddrERC20},
+		{ID: cryptocurrency.TronNetwork, Name: "TRON", Family: FamilyTron, Token: AddrTRC20},
+		{ID: cryptocurrency.BtcNetwork, Name: "Bitcoin", Family: FamilyBTC},
+		{ID: cryptocurrency.BtcTestNetwork, Name: "Bitcoin Testnet", Family: FamilyBTC, Testnet: true},
+		{ID: cryptocurrency.TonNetwork, Name: "TON", Family: FamilyTON},
+		{ID: cryptocurrency.SolNetwork, Name: "Solana", Family: FamilySOL, Token: AddrType(cryptocurrency.SPL)},
+		{ID: cryptocurrency.LtcNetwork, Name: "Litecoin", Family: FamilyLTC},
+		{ID: cryptocurrency.DogeNetwork, Name: "Dogecoin", Family: FamilyDOGE},
+		{ID: cryptocurrency.XrpNetwork, Name: "XRP Ledger", Family: FamilyXRP},
+		{ID: cryptocurrency.CosmosNetwork, Name: "Cosmos Hub", Family: FamilyATOM},
+		{ID: cryptocurrency.BchNetwork, Name: "Bitcoin Cash", Family: FamilyBCH},
+	} {
+		if err := RegisterNetwork(n); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNetwork 注册（或替换）网络。常用于追加其它 EVM 链，如 Optimism / Base。
+func RegisterNetwork(n Network) error {
+	if n.ID == "" {
+		return errors.New("network id is empty")
+	}
+	if familyValidators[n.Family] == nil {
+		return fmt.Errorf("network %s: unsupported family %q", n.ID, n.Family)
+	}
+	networksMu.Lock()
+	networks[n.ID] = n
+	networksMu.Unlock()
+	return nil
+}
+
+// GetNetwork 按 ID 查询网络。
+func GetNetwork(id string) (Network, bool) {
+	networksMu.RLock()
+	defer networksMu.RUnlock()
+	n, ok := networks[id]
+	return n, ok
+}
+
+// Networks 返回全部已注册网络（按 ID 排序）。
+func Networks() []Network {
+	networksMu.RLock()
+	out := make([]Network, 0, len(networks))
+	for _, n := range networks {
+		out = append(out, n)
+	}
+	networksMu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// ValidateAddress 按用户选择的网络校验地址，成功时返回规范化地址：
+//   - EVM：EIP-55 校验大小写形式
+//   - BTC：bech32 转小写，Base58 原样
+//   - TRON：原样
+//   - TON：raw 形式转小写，friendly 原样
+//   - LTC：bech32 转小写；BCH：补全 "bitcoincash:" 前缀并转小写
+//   - XRP：X-address 原样（调用方可用 ParseXRPAddress 取出 classic 地址与标签）
+//
+// 失败时返回 *AddressError（ErrUnknownNetwork 除外）。
+func ValidateAddress(network, addr string) (string, error) {
+	n, ok := GetNetwork(network)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+	a := strings.TrimSpace(addr)
+	if a == "" {
+		return "", &AddressError{Network: n.ID, Address: addr, Reasons: []error{ErrAddressEmpty}}
+	}
+	normalized, reasons := familyValidators[n.Family](n, a)
+	if len(reasons) == 0 {
+		return normalized, nil
+	}
+
+	e := &AddressError{Network: n.ID, Address: addr, Reasons: reasons}
+	for _, other := range DetectNetworks(a) {
+		e.Candidates = append(e.Candidates, other.ID)
+	}
+	if len(e.Candidates) > 0 && !errors.Is(e, ErrAddressTestnet) && !errors.Is(e, ErrAddressMainnet) {
+		e.Reasons = append(e.Reasons, ErrAddressOtherNetwork)
+	}
+	return "", e
+}
+
+// DetectNetworks 返回地址可通过校验的全部网络（按 ID 排序）。
+// EVM 地址会同时命中 ETH / BSC / Polygon / Arbitrum——仅凭字符串无法区分链，需由用户选择。
+func DetectNetworks(addr string) []Network {
+	a := strings.TrimSpace(addr)
+	var out []Network
+	for _, n := range Networks() {
+		if _, reasons := familyValidators[n.Family](n, a); len(reasons) == 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// -----------------------------
+// 各地址族的详细校验
+// -----------------------------
+
+type familyValidator func(n Network, addr string) (string, []error)
+
+var familyValidators = map[Family]familyValidator{
+	FamilyEVM:  validateEVMFamily,
+	FamilyTron: validateTronFamily,
+	FamilyBTC:  validateBTCFamily,
+	FamilyTON:  validateTONFamily,
+	FamilySOL:  validateSolanaFamily,
+	FamilyLTC:  validateLTCFamily,
+	FamilyDOGE: validateDOGEFamily,
+	FamilyXRP:  validateXRPFamily,
+	FamilyATOM: validateATOMFamily,
+	FamilyBCH:  validateBCHFamily,
+}
+
+func validateEVMFamily(_ Network, a string) (string, []error) {
+	if len(a) != 42 || !strings.HasPrefix(a, "0x") && !strings.HasPrefix(a, "0X") || !isHexString(a[2:]) {
+		return "", []error{ErrAddressFormat}
+	}
+	hexPart := a[2:]
+	if !isAllLower(hexPart) && !isAllUpper(hexPart) && !validateEIP55(a) {
+		return "", []error{ErrAddressChecksum}
+	}
+	return eip55Checksum(hexPart), nil
+}
+
+func validateTronFamily(_ Network, a string) (string, []error) {
+	version, _, err := base58CheckDecode(a)
+	if err != nil {
+		return "", []error{err}
+	}
+	if version != 0x41 {
+		return "", []error{ErrAddressFormat}
+	}
+	return a, nil
+}
+
+func validateBTCFamily(n Network, a string) (string, []error) {
+	info, err := DecodeBTCAddress(a)
+	if err != nil {
+		return "", []error{reasonOf(err)}
+	}
+	// BTC-TEST 同时接受 testnet 与 regtest
+	if testnet := info.Network != BTCMainnet; testnet != n.Testnet {
+		if testnet {
+			return "", []error{ErrAddressTestnet}
+		}
+		return "", []error{ErrAddressMainnet}
+	}
+	return info.Address, nil
+}
+
+func validateTONFamily(n Network, a string) (string, []error) {
+	if isTONRaw(a) {
+		return strings.ToLower(a), nil
+	}
+	decoded, err := decodeTONFriendly(a)
+	if err != nil {
+		return "", []error{err}
+	}
+	if testnet := decoded[0]&tonTagTestnet != 0; testnet != n.Testnet {
+		if testnet {
+			return "", []error{ErrAddressTestnet}
+		}
+		return "", []error{ErrAddressMainnet}
+	}
+	return a, nil
+}
+
+func validateSolanaFamily(_ Network, a string) (string, []error) {
+	if !IsValidSolanaAddress(a) {
+		return "", []error{ErrAddressFormat}
+	}
+	return a, nil
+}
+
+func validateLTCFamily(_ Network, a string) (string, []error) {
+	normalized, err := normalizeLTCAddress(a)
+	if err != nil {
+		return "", []error{reasonOf(err)}
+	}
+	return normalized, nil
+}
+
+func validateDOGEFamily(_ Network, a string) (string, []error) {
+	version, _, err := base58CheckDecode(a)
+	if err != nil {
+		return "", []error{err}
+	}
+	if version != 0x1e && version != 0x16 {
+		return "", []error{ErrAddressFormat}
+	}
+	return a, nil
+}
+
+func validateXRPFamily(_ Network, a string) (string, []error) {
+	parsed, err := ParseXRPAddress(a)
+	if err != nil {
+		return "", []error{reasonOf(err)}
+	}
+	if parsed.Testnet {
+		return "", []error{ErrAddressTestnet}
+	}
+	return a, nil
+}
+
+func validateATOMFamily(_ Network, a string) (string, []error) {
+	hrp, _, err := DecodeCosmosAddress(a)
+	if err != nil {
+		return "", []error{reasonOf(err)}
+	}
+	if hrp != "cosmos" {
+		return "", []error{ErrAddressFormat}
+	}
+	return strings.ToLower(a), nil
+}
+
+func validateBCHFamily(_ Network, a string) (string, []error) {
+	normalized, _, _, err := DecodeBCHAddress(a)
+	if err != nil {
+		return "", []error{reasonOf(err)}
+	}
+	return normalized, nil
+}
+
+// reasonOf 把包装过的错误还原为哨兵原因。
+func reasonOf(err error) error {
+	if errors.Is(err, ErrAddressChecksum) {
+		return ErrAddressChecksum
+	}
+	return ErrAddressFormat
+}
+
+// base58CheckDecode 解码 25 字节的 Base58Check（1 版本 + 20 载荷 + 4 校验），
+// 失败时返回 ErrAddressFormat / ErrAddressChecksum。
+func base58CheckDecode(a string) (version byte, payload []byte, err error) {
+	raw, err := base58Decode(a)
+	if err != nil || len(raw) != 25 {
+		return 0, nil, ErrAddressFormat
+	}
+	h1 := sha256.Sum256(raw[:21])
+	h2 := sha256.Sum256(h1[:])
+	if !bytes.Equal(raw[21:], h2[:4]) {
+		return 0, nil, ErrAddressChecksum
+	}
+	return raw[0], raw[1:21], nil
+}
@@ -0,0 +1,299 @@
+package cryptocoin
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+)
+
+// -----------------------------
+// 收款 URI（二维码内容）：BIP-21 / EIP-681 / Tron / TON transfer
+// -----------------------------
+//
+// 充值页用 PaymentRequest.URI 生成二维码内容，扫码端用 ParsePaymentURI 解析回来。
+// 金额一律以最小单位（satoshi / wei / sun / nanoton）的整数表示，
+// 只有 BIP-21 的 amount 按规范写成 BTC 小数，在编解码时换算。
+//
+//	bitcoin:<address>?amount=<BTC>&label=<label>&message=<message>
+//	ethereum:<address>@<chainId>?value=<wei>
+//	ethereum:<token>@<chainId>/transfer?address=<address>&uint256=<raw>
+//	tron:<address>?amount=<sun>&token=<contract>
+//	ton://transfer/<address>?amount=<nanoton>&text=<comment>&jetton=<master>
+//
+// tron: 没有统一的标准，这里沿用 EIP-681 的思路，仅保证本库生成的 URI 能被本库解析。
+
+var ErrPaymentURI = errors.New("invalid payment URI")
+
+// btcDecimals BIP-21 amount 的小数位数（1 BTC = 1e8 satoshi）。
+const btcDecimals = 8
+
+// PaymentRequest 一笔收款请求。
+type PaymentRequest struct {
+	Network string   // 网络 ID，复用 consts/cryptocurrency 中的网络名称常量
+	Address string   // 收款地址
+	Token   string   // 代币合约（ERC-20 / TRC-20）或 jetton master 地址，空为原生币
+	Amount  *big.Int // 最小单位金额，nil 表示由付款方填写
+	Label   string   // 收款方名称，仅 BIP-21
+	Message string   // 付款说明：BIP-21 message / TON text（交易所充值备注放这里）
+}
+
+// URI 生成收款 URI。地址与代币合约按 Network 校验并规范化，
+// Label / Message 在不支持的格式中被忽略（EVM、Tron 没有备注字段）。
+func (r PaymentRequest) URI() (string, error) {
+	n, ok := GetNetwork(r.Network)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownNetwork, r.Network)
+	}
+	addr, err := ValidateAddress(n.ID, r.Address)
+	if err != nil {
+		return "", err
+	}
+	token := ""
+	if r.Token != "" {
+		if token, err = ValidateAddress(n.ID, r.Token); err != nil {
+			return "", fmt.Errorf("token: %w", err)
+		}
+	}
+	if r.Amount != nil && r.Amount.Sign() < 0 {
+		return "", fmt.Errorf("%w: negative amount", ErrPaymentURI)
+	}
+
+	var q uriQuery
+	switch n.Family {
+	case FamilyBTC:
+		if token != "" {
+			return "", fmt.Errorf("%w: bitcoin has no tokens", ErrPaymentURI)
+		}
+		if r.Amount != nil {
+			q.add("amount", formatUnits(r.Amount, btcDecimals))
+		}
+		q.add("label", r.Label)
+		q.add("message", r.Message)
+		return "bitcoin:" + addr + q.String(), nil
+
+	case FamilyEVM:
+		if token == "" {
+			if r.Amount != nil {
+				q.add("value", r.Amount.String())
+			}
+			return fmt.Sprintf("ethereum:%s@%d%s", addr, n.ChainID, q), nil
+		}
+		q.add("address", addr)
+		if r.Amount != nil {
+			q.add("uint256", r.Amount.String())
+		}
+		return fmt.Sprintf("ethereum:%s@%d/transfer%s", token, n.ChainID, q), nil
+
+	case FamilyTron:
+		if r.Amount != nil {
+			q.add("amount", r.Amount.String())
+		}
+		q.add("token", token)
+		return "tron:" + addr + q.String(), nil
+
+	case FamilyTON:
+		if r.Amount != nil {
+			q.add("amount", r.Amount.String())
+		}
+		q.add("text", r.Message)
+		q.add("jetton", token)
+		return "ton://transfer/" + addr + q.String(), nil
+
+	default:
+		return "", fmt.Errorf("%w: no URI scheme for network %s", ErrPaymentURI, n.ID)
+	}
+}
+
+// ParsePaymentURI 解析 bitcoin: / ethereum: / tron: / ton://transfer/ URI，地址经 ValidateAddress 校验后返回规范化形式。
+//   - BIP-21：按地址前缀区分 BTC 与 BTC-TEST；出现未知的 req-* 参数时按规范拒绝
+//   - EIP-681：按 chainId 匹配已注册的 EVM 网络，省略时为以太坊主网；value / uint256 允许 2.014e18 这样的科学计数法
+func ParsePaymentURI(s string) (PaymentRequest, error) {
+	s = strings.TrimSpace(s)
+	scheme, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		return PaymentRequest{}, fmt.Errorf("%w: missing scheme", ErrPaymentURI)
+	}
+	path, rawQuery, _ := strings.Cut(rest, "?")
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return PaymentRequest{}, fmt.Errorf("%w: %v", ErrPaymentURI, err)
+	}
+
+	var r PaymentRequest
+	switch strings.ToLower(scheme) {
+	case "bitcoin":
+		err = r.parseBIP21(path, q)
+	case "ethereum":
+		err = r.parseEIP681(path, q)
+	case "tron":
+		r.Network, r.Address, r.Token = cryptocurrency.TronNetwork, path, q.Get("token")
+		r.Amount, err = parseUintParam(q, "amount")
+	case "ton":
+		addr, ok := strings.CutPrefix(path, "//transfer/")
+		if !ok {
+			return PaymentRequest{}, fmt.Errorf("%w: expected ton://transfer/", ErrPaymentURI)
+		}
+		r.Network, r.Address, r.Token, r.Message = cryptocurrency.TonNetwork, addr, q.Get("jetton"), q.Get("text")
+		r.Amount, err = parseUintParam(q, "amount")
+	default:
+		return PaymentRequest{}, fmt.Errorf("%w: unsupported scheme %q", ErrPaymentURI, scheme)
+	}
+	if err != nil {
+		return PaymentRequest{}, err
+	}
+
+	if r.Address, err = ValidateAddress(r.Network, r.Address); err != nil {
+		return PaymentRequest{}, err
+	}
+	if r.Token != "" {
+		if r.Token, err = ValidateAddress(r.Network, r.Token); err != nil {
+			return PaymentRequest{}, fmt.Errorf("token: %w", err)
+		}
+	}
+	return r, nil
+}
+
+func (r *PaymentRequest) parseBIP21(path string, q url.Values) error {
+	info, err := DecodeBTCAddress(path)
+	if err != nil {
+		return err
+	}
+	r.Network = cryptocurrency.BtcNetwork
+	if info.Network != BTCMainnet {
+		r.Network = cryptocurrency.BtcTestNetwork
+	}
+	r.Address, r.Label, r.Message = path, q.Get("label"), q.Get("message")
+	for k := range q {
+		if strings.HasPrefix(k, "req-") {
+			return fmt.Errorf("%w: unsupported required parameter %q", ErrPaymentURI, k)
+		}
+	}
+	if v := q.Get("amount"); v != "" {
+		if r.Amount, err = parseUnits(v, btcDecimals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PaymentRequest) parseEIP681(path string, q url.Values) error {
+	path = strings.TrimPrefix(path, "pay-")
+	target, fn, _ := strings.Cut(path, "/")
+	target, chain, hasChain := strings.Cut(target, "@")
+
+	chainID := uint64(1)
+	if hasChain {
+		id, err := strconv.ParseUint(chain, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: chain id %q", ErrPaymentURI, chain)
+		}
+		chainID = id
+	}
+	n, ok := evmNetworkByChainID(chainID)
+	if !ok {
+		return fmt.Errorf("%w: chain id %d", ErrUnknownNetwork, chainID)
+	}
+	r.Network = n.ID
+
+	var err error
+	switch fn {
+	case "":
+		r.Address = target
+		r.Amount, err = parseEIP681Number(q, "value")
+	case "transfer":
+		r.Address, r.Token = q.Get("address"), target
+		r.Amount, err = parseEIP681Number(q, "uint256")
+	default:
+		return fmt.Errorf("%w: unsupported function %q", ErrPaymentURI, fn)
+	}
+	return err
+}
+
+// evmNetworkByChainID 按链 ID 查找已注册的 EVM 网络（多个匹配时取 ID 最小者）。
+func evmNetworkByChainID(id uint64) (Network, bool) {
+	for _, n := range Networks() {
+		if n.Family == FamilyEVM && n.ChainID == id {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+// parseEIP681Number EIP-681 的 number：十进制，可带小数与指数，但结果必须是非负整数。
+func parseEIP681Number(q url.Values, key string) (*big.Int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	rat, ok := new(big.Rat).SetString(v)
+	if !ok || rat.Sign() < 0 || !rat.IsInt() || strings.ContainsAny(v, "xX/") {
+		return nil, fmt.Errorf("%w: %s=%q", ErrPaymentURI, key, v)
+	}
+	return new(big.Int).Set(rat.Num()), nil
+}
+
+// parseUintParam 最小单位的非负整数金额。
+func parseUintParam(q url.Values, key string) (*big.Int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, ok := new(big.Int).SetString(v, 10)
+	if !ok || n.Sign() < 0 || strings.HasPrefix(v, "+") {
+		return nil, fmt.Errorf("%w: %s=%q", ErrPaymentURI, key, v)
+	}
+	return n, nil
+}
+
+// parseUnits 把 "0.0015" 这样的小数换算为最小单位整数，小数位超过 decimals 视为错误。
+func parseUnits(s string, decimals int) (*big.Int, error) {
+	intPart, frac, _ := strings.Cut(s, ".")
+	if intPart == "" && frac == "" || !isDigits(intPart) || !isDigits(frac) || len(frac) > decimals {
+		return nil, fmt.Errorf("%w: amount %q", ErrPaymentURI, s)
+	}
+	n, _ := new(big.Int).SetString(intPart+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	return n, nil
+}
+
+// formatUnits parseUnits 的逆运算，去掉小数部分末尾的 0。
+func formatUnits(n *big.Int, decimals int) string {
+	s := n.String()
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+	intPart, frac := s[:len(s)-decimals], strings.TrimRight(s[len(s)-decimals:], "0")
+	if frac == "" {
+		return intPart
+	}
+	return intPart + "." + frac
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// uriQuery 保持参数顺序的查询串，空值跳过；空格编码为 %20 而非 +，部分钱包不认 +。
+type uriQuery []string
+
+func (q *uriQuery) add(key, value string) {
+	if value != "" {
+		*q = append(*q, key+"="+strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+	}
+}
+
+func (q uriQuery) String() string {
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(q, "&")
+}
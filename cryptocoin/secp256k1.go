@@ -0,0 +1,72 @@
+package cryptocoin
+
+import (
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// -----------------------------
+// secp256k1（BTC / EVM / Tron 共用）
+// -----------------------------
+//
+// 曲线运算全部委托给 decred 的 secp256k1 实现（btcd / lnd 等同样使用），
+// 这里只做 HD 派生、地址生成与验签所需的薄封装。
+
+var (
+	errInvalidPubKey    = errors.New("invalid secp256k1 public key")
+	errInvalidSignature = errors.New("invalid secp256k1 signature")
+)
+
+// secpScalar 把 32 字节大端整数解析为模 n 标量，值 >= n 时返回 false。
+func secpScalar(b []byte) (*secp256k1.ModNScalar, bool) {
+	var s secp256k1.ModNScalar
+	if len(b) != 32 || s.SetByteSlice(b) {
+		return nil, false
+	}
+	return &s, true
+}
+
+// secpPubKeyFromPriv 私钥对应的公钥。
+func secpPubKeyFromPriv(priv []byte) *secp256k1.PublicKey {
+	return secp256k1.PrivKeyFromBytes(priv).PubKey()
+}
+
+// secpParsePubKey 解析 33 字节压缩或 65 字节未压缩公钥，并校验点在曲线上。
+func secpParsePubKey(b []byte) (*secp256k1.PublicKey, error) {
+	p, err := secp256k1.ParsePubKey(b)
+	if err != nil {
+		return nil, errInvalidPubKey
+	}
+	return p, nil
+}
+
+// secpAddScalarBase 计算 k·G + P，结果为无穷远点时返回 false。
+func secpAddScalarBase(k *secp256k1.ModNScalar, p *secp256k1.PublicKey) (*secp256k1.PublicKey, bool) {
+	var kg, pj, sum secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(k, &kg)
+	p.AsJacobian(&pj)
+	secp256k1.AddNonConst(&kg, &pj, &sum)
+	if (sum.X.IsZero() && sum.Y.IsZero()) || sum.Z.IsZero() {
+		return nil, false
+	}
+	sum.ToAffine()
+	return secp256k1.NewPublicKey(&sum.X, &sum.Y), true
+}
+
+// secpRecover 由 32 字节哈希与 r(32) || s(32) || recid 还原签名公钥，recid 取 0-3。
+func secpRecover(hash, r, s []byte, recid byte) (*secp256k1.PublicKey, error) {
+	if recid > 3 {
+		return nil, errInvalidSignature
+	}
+	compact := make([]byte, 0, 65)
+	compact = append(compact, 27+recid)
+	compact = append(compact, r...)
+	compact = append(compact, s...)
+	p, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, errInvalidSignature
+	}
+	return p, nil
+}
@@ -0,0 +1,122 @@
+package cryptocoin
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/sha3"
+)
+
+// -----------------------------
+// 钱包签名验证：EVM personal_sign / Tron signMessageV2
+// -----------------------------
+//
+// 钱包登录流程：服务端下发带随机数的消息，前端调用钱包签名，服务端用 Verify* 校验
+// 签名恢复出的地址与用户声明的地址一致。随机数、过期时间等防重放逻辑由业务层负责。
+
+var (
+	ErrSignatureFormat   = errors.New("malformed signature")
+	ErrSignatureMismatch = errors.New("signature does not match address")
+)
+
+// PersonalMessageHash EIP-191 personal_sign 的消息哈希：
+// Keccak256("\x19Ethereum Signed Message:\n" + len(msg) + msg)。
+func PersonalMessageHash(msg []byte) []byte {
+	return keccak256([]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(msg))), msg)
+}
+
+// RecoverPersonalSign 从 personal_sign 签名（65 字节 hex，可带 0x）恢复签名地址，返回 EIP-55 形式。
+func RecoverPersonalSign(msg []byte, sigHex string) (string, error) {
+	return recoverEVM(PersonalMessageHash(msg), sigHex)
+}
+
+// VerifyPersonalSign 校验 personal_sign 签名是否出自 address，地址大小写不敏感（混合大小写须符合 EIP-55）。
+func VerifyPersonalSign(address string, msg []byte, sigHex string) error {
+	return verifyEVM(address, PersonalMessageHash(msg), sigHex)
+}
+
+// TronMessageHash TronWeb signMessageV2 的消息哈希：
+// Keccak256("\x19TRON Signed Message:\n" + len(msg) + msg)。
+func TronMessageHash(msg []byte) []byte {
+	return keccak256([]byte("\x19TRON Signed Message:\n"+strconv.Itoa(len(msg))), msg)
+}
+
+// RecoverTronMessage 从 signMessageV2 签名恢复 Tron 地址（T...）。
+func RecoverTronMessage(msg []byte, sigHex string) (string, error) {
+	p, err := recoverPubKey(TronMessageHash(msg), sigHex)
+	if err != nil {
+		return "", err
+	}
+	return base58CheckEncode(0x41, pubKeyToEVMHash(p)), nil
+}
+
+// VerifyTronMessage 校验 signMessageV2 签名是否出自 Tron 地址 address。
+func VerifyTronMessage(address string, msg []byte, sigHex string) error {
+	if !IsValidTRC20Address(address) {
+		return fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+	got, err := RecoverTronMessage(msg, sigHex)
+	if err != nil {
+		return err
+	}
+	if got != address {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func recoverEVM(hash []byte, sigHex string) (string, error) {
+	p, err := recoverPubKey(hash, sigHex)
+	if err != nil {
+		return "", err
+	}
+	return eip55Checksum(hex.EncodeToString(pubKeyToEVMHash(p))), nil
+}
+
+func verifyEVM(address string, hash []byte, sigHex string) error {
+	if !IsValidEVMAddress(address) {
+		return fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+	got, err := recoverEVM(hash, sigHex)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(got[2:], address[2:]) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// recoverPubKey 解析 r(32) || s(32) || v(1) 形式的签名并恢复公钥，v 接受 0/1 与 27/28。
+func recoverPubKey(hash []byte, sigHex string) (*secp256k1.PublicKey, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(sigHex), "0x"), "0X"))
+	if err != nil || len(sig) != 65 {
+		return nil, ErrSignatureFormat
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	p, err := secpRecover(hash, sig[:32], sig[32:64], v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSignatureFormat, err)
+	}
+	return p, nil
+}
+
+// pubKeyToEVMHash Keccak256(X||Y) 的后 20 字节，EVM 与 Tron 地址共用。
+func pubKeyToEVMHash(p *secp256k1.PublicKey) []byte {
+	return keccak256(p.SerializeUncompressed()[1:])[12:]
+}
+
+func keccak256(parts ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,144 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// -----------------------------
+// TON Connect ton_proof 验证
+// -----------------------------
+
+var (
+	ErrTONProofDomain    = errors.New("ton_proof domain mismatch")
+	ErrTONProofExpired   = errors.New("ton_proof timestamp out of range")
+	ErrTONProofStateInit = errors.New("ton_proof state init does not match address or public key")
+)
+
+// TONProof TON Connect 钱包连接时回传的 ton_proof（对应 account 与 proof 字段）。
+type TONProof struct {
+	Address   string // account.address，raw 或 friendly
+	Domain    string // proof.domain.value
+	Timestamp int64  // proof.timestamp，Unix 秒
+	Payload   string // proof.payload，服务端下发的一次性随机串
+	Signature string // proof.signature，Base64
+	StateInit string // account.walletStateInit，Base64 BOC；可为空
+}
+
+// SignedHash 钱包实际签名的 32 字节：
+// SHA256(0xffff || "ton-connect" || SHA256("ton-proof-item-v2/" || wc(4,BE) || account || len(domain)(4,LE) || domain || timestamp(8,LE) || payload))。
+func (p TONProof) SignedHash() ([32]byte, error) {
+	addr, err := ParseTONAddress(p.Address)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var msg bytes.Buffer
+	msg.WriteString("ton-proof-item-v2/")
+	_ = binary.Write(&msg, binary.BigEndian, int32(addr.Workchain))
+	msg.Write(addr.Account[:])
+	_ = binary.Write(&msg, binary.LittleEndian, uint32(len(p.Domain)))
+	msg.WriteString(p.Domain)
+	_ = binary.Write(&msg, binary.LittleEndian, uint64(p.Timestamp))
+	msg.WriteString(p.Payload)
+	inner := sha256.Sum256(msg.Bytes())
+
+	outer := append([]byte{0xff, 0xff}, "ton-connect"...)
+	return sha256.Sum256(append(outer, inner[:]...)), nil
+}
+
+// VerifyTONProof 校验 ton_proof：
+//   - domain 非空时必须与 proof 中的域名一致（防止其他站点转发签名）
+//   - maxAge > 0 时时间戳与当前时间相差不得超过 maxAge
+//   - 提供 StateInit 时，其哈希必须等于地址、且 data 中包含 pub
+//   - ed25519 签名有效
+//
+// pub 为钱包公钥。未提供 StateInit（钱包已部署）时，pub 必须来自链上（get_public_key），
+// 不能直接信任前端上报的公钥。Payload 是否为本服务下发且未使用，由业务层检查。
+func VerifyTONProof(p TONProof, pub ed25519.PublicKey, domain string, maxAge time.Duration) error {
+	if len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: public key must be %d bytes", ErrSignatureFormat, ed25519.PublicKeySize)
+	}
+	if domain != "" && p.Domain != domain {
+		return ErrTONProofDomain
+	}
+	if maxAge > 0 {
+		age := time.Since(time.Unix(p.Timestamp, 0))
+		if age > maxAge || age < -maxAge {
+			return ErrTONProofExpired
+		}
+	}
+	if p.StateInit != "" {
+		if err := checkTONStateInit(p.Address, p.StateInit, pub); err != nil {
+			return err
+		}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return ErrSignatureFormat
+	}
+	h, err := p.SignedHash()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, h[:], sig) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// checkTONStateInit StateInit 根 cell 哈希须等于账户地址，且 data cell 中含有公钥。
+// 各钱包版本公钥在 data 中的位偏移不同（v3/v4 为 64，v5 为 65），这里按位搜索。
+func checkTONStateInit(address, stateInit string, pub ed25519.PublicKey) error {
+	addr, err := ParseTONAddress(address)
+	if err != nil {
+		return err
+	}
+	boc, err := base64.StdEncoding.DecodeString(stateInit)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBOC, err)
+	}
+	cells, root, err := parseBOC(boc)
+	if err != nil {
+		return err
+	}
+	r := cells[root]
+	if r.hash != addr.Account {
+		return ErrTONProofStateInit
+	}
+	// 常规 StateInit：split_depth 与 special 缺省，code 与 data 均存在（前 4 位 0011）
+	if len(r.data) == 0 || r.data[0]>>4 != 0b0011 || len(r.refs) < 2 {
+		return ErrTONProofStateInit
+	}
+	if !containsBits(cells[r.refs[1]].data, pub) {
+		return ErrTONProofStateInit
+	}
+	return nil
+}
+
+// containsBits data 的位串中是否在任意位偏移处包含 needle。
+func containsBits(data, needle []byte) bool {
+	total, n := len(data)*8, len(needle)*8
+	for off := 0; off+n <= total; off++ {
+		if off%8 == 0 {
+			if bytes.Equal(data[off/8:off/8+len(needle)], needle) {
+				return true
+			}
+			continue
+		}
+		match := true
+		for i := 0; i < n && match; i++ {
+			match = readBits(data, off+i, 1) == readBits(needle, i, 1)
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -3,7 +3,6 @@ package cryptocoin
 import (
 	"bytes"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"strings"
@@ -21,6 +20,12 @@ const (
 	AddrTRC20   AddrType = "TRC20" // Tron
 	AddrBTC     AddrType = "BTC"   // Bitcoin
 	AddrTON     AddrType = "TON"   // The Open Network
+	AddrSOL     AddrType = "SOL"   // Solana
+	AddrLTC     AddrType = "LTC"   // Litecoin
+	AddrDOGE    AddrType = "DOGE"  // Dogecoin
+	AddrXRP     AddrType = "XRP"   // XRP Ledger
+	AddrATOM    AddrType = "ATOM"  // Cosmos Hub
+	AddrBCH     AddrType = "BCH"   // Bitcoin Cash（CashAddr）
 )
 
 // -----------------------------
@@ -29,6 +34,7 @@ const (
 
 // DetectAddress 统一识别地址类型。
 // 注意：EVM 地址（ERC20/BEP20）仅凭字符串不可区分链，优先返回 ERC20；若你要“标注为 BSC”，可在外层根据业务语境二次映射。
+// 已知用户所选网络时请用 ValidateAddress，需要列出全部候选网络时用 DetectNetworks。
 func DetectAddress(addr string) (AddrType, bool) {
 	a := strings.TrimSpace(addr)
 
@@ -49,6 +55,26 @@ func DetectAddress(addr string) (AddrType, bool) {
 		// 这里默认标记为 ERC20；如需区分 BSC，请在业务层结合链 ID/RPC 判断后再改写为 BEP20
 		return AddrERC20, true
 	}
+	// LTC / DOGE / XRP / Cosmos / BCH 前缀或版本字节各不相同，互不冲突
+	if IsValidLTCAddress(a) {
+		return AddrLTC, true
+	}
+	if IsValidDOGEAddress(a) {
+		return AddrDOGE, true
+	}
+	if IsValidXRPAddress(a) {
+		return AddrXRP, true
+	}
+	if IsValidATOMAddress(a) {
+		return AddrATOM, true
+	}
+	if IsValidBCHAddress(a) {
+		return AddrBCH, true
+	}
+	// Solana 只要求 Base58 解码为 32 字节，判定最宽松，放在最后
+	if IsValidSolanaAddress(a) {
+		return AddrSOL, true
+	}
 	return AddrUnknown, false
 }
 
@@ -82,16 +108,21 @@ func IsValidERC20Address(address string) bool { return IsValidEVMAddress(address
 func IsValidBEP20Address(address string) bool { return IsValidEVMAddress(address) }
 
 func validateEIP55(addr string) bool {
-	// addr: 0x + 40
-	addr = strings.TrimPrefix(strings.ToLower(addr), "0x")
+	// addr: 0x + 40，混合大小写必须与 EIP-55 计算结果逐字符一致
+	return eip55Checksum(addr[2:]) == "0x"+addr[2:]
+}
+
+// eip55Checksum 对 40 位 hex（大小写不限）计算 EIP-55 校验大小写形式，返回带 0x 前缀的地址。
+func eip55Checksum(hexAddr string) string {
+	lower := strings.ToLower(hexAddr)
 	// 计算 keccak256
 	hasher := sha3.NewLegacyKeccak256()
-	_, _ = hasher.Write([]byte(addr))
+	_, _ = hasher.Write([]byte(lower))
 	sum := hasher.Sum(nil)
 	// 生成校验大小写形式
 	var checksummed strings.Builder
 	checksummed.WriteString("0x")
-	for i, c := range addr {
+	for i, c := range lower {
 		if c >= '0' && c <= '9' {
 			checksummed.WriteRune(c)
 			continue
@@ -110,12 +141,7 @@ func validateEIP55(addr string) bool {
 			checksummed.WriteRune(c)
 		}
 	}
-	return checksummed.String() == addrWithPrefixCase(addr, "0x", true, addr)
-}
-
-func addrWithPrefixCase(lowerHex string, prefix string, withCase bool, originalLower string) string {
-	// 当 withCase=true 时，validateEIP55 构造出的大小写敏感字符串；否则只是占位
-	return prefix + lowerHex
+	return checksummed.String()
 }
 
 func isHexString(s string) bool {
@@ -173,56 +199,24 @@ func IsValidTRC20Address(address string) bool {
 }
 
 // -----------------------------
-// BTC：Base58Check + Bech32
+// BTC：Base58Check + Bech32/Bech32m（详见 btc.go）
 // -----------------------------
 
-// IsValidBTCAddress 支持：
+// IsValidBTCAddress 校验比特币主网地址：
 // - Base58Check：P2PKH(version=0x00)、P2SH(version=0x05)
-// - Bech32：HRP=bc，见 BIP-0173
+// - SegWit：HRP=bc，v0 使用 Bech32（BIP-0173），v1+（Taproot 等）使用 Bech32m（BIP-0350）
+//
+// 需要地址类型、网络或哈希时使用 DecodeBTCAddress。
 func IsValidBTCAddress(address string) bool {
-	a := strings.TrimSpace(address)
-
-	// 优先判断 Bech32（bc1...）
-	if strings.HasPrefix(strings.ToLower(a), "bc1") {
-		hrp, data, err := bech32Decode(a)
-		if err != nil {
-			return false
-		}
-		if hrp != "bc" {
-			return false
-		}
-		// 转换为 5-bit，检查编码长度与校验和已在 decode 中完成
-		// 这里可选：进一步解析 witness version / program 长度范围
-		if len(data) < 6 || len(data) > 90 { // 经验范围
-			return false
-		}
-		return true
-	}
-
-	// Base58Check
-	raw, err := base58Decode(a)
-	if err != nil {
-		return false
-	}
-	if len(raw) != 25 {
-		return false
-	}
-	version := raw[0]
-	if version != 0x00 && version != 0x05 { // mainnet P2PKH / P2SH
-		return false
-	}
-	payload := raw[:21]
-	checksum := raw[21:]
-	h1 := sha256.Sum256(payload)
-	h2 := sha256.Sum256(h1[:])
-	return bytes.Equal(checksum, h2[:4])
+	info, err := DecodeBTCAddress(address)
+	return err == nil && info.Network == BTCMainnet
 }
 
 // -----------------------------
 // TON：Friendly(Base64URL) + Raw
 // -----------------------------
 
-// IsValidTONAddress 既支持 Friendly（Base64URL 无 padding），也支持 Raw（wc:hex32bytes）
+// IsValidTONAddress 既支持 Friendly（Base64URL / Base64 无 padding），也支持 Raw（wc:hex32bytes）
 func IsValidTONAddress(address string) bool {
 	a := strings.TrimSpace(address)
 
@@ -231,26 +225,9 @@ func IsValidTONAddress(address string) bool {
 		return true
 	}
 
-	// 2) Friendly: Base64URL 无填充，解码后 36 字节：
-	// [1字节tag][1字节workchain][32字节accountID][2字节CRC16-XMODEM]
-	// 常用 tag: 0x11(bounceable), 0x51(non-bounceable); bit7 可表示 testnet
-	decoded, err := base64.RawURLEncoding.DecodeString(a)
-	if err != nil {
-		return false
-	}
-	if len(decoded) != 36 {
-		return false
-	}
-	tag := decoded[0]
-	// tag 合法性（宽松检查）：低 6 位 0x11 或 0x51；不强制 testnet 位
-	okTag := (tag & 0x1F) == 0x11 // 宽松处理
-	_ = okTag                     // 这里不严格限制 tag，兼容性更强
-
-	// CRC16-XMODEM 校验
-	body := decoded[:34]
-	gotCRC := decoded[34:]
-	want := crc16XModem(body)
-	return bytes.Equal(gotCRC, want)
+	// 2) Friendly: Base64URL / Base64 无填充，校验 tag 与 CRC16-XMODEM
+	_, err := decodeTONFriendly(a)
+	return err == nil
 }
 
 func isTONRaw(a string) bool {
@@ -272,12 +249,17 @@ func isTONRaw(a string) bool {
 }
 
 // -----------------------------
-// Base58 / Bech32 / CRC16 辅助
+// Base58 / CRC16 辅助
 // -----------------------------
 
 var b58Alphabet = []byte("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
 
 func base58Decode(input string) ([]byte, error) {
+	return base58DecodeWith(input, b58Alphabet)
+}
+
+// base58DecodeWith 用指定字母表解码（比特币字母表 / XRP 的 ripple 字母表）。
+func base58DecodeWith(input string, alphabet []byte) ([]byte, error) {
 	// strip spaces
 	in := []byte(strings.TrimSpace(input))
 	if len(in) == 0 {
@@ -285,7 +267,7 @@ func base58Decode(input string) ([]byte, error) {
 	}
 	// map
 	alphabetMap := make(map[byte]int)
-	for i, b := range b58Alphabet {
+	for i, b := range alphabet {
 		alphabetMap[b] = i
 	}
 	num := make([]int, 0, len(in))
@@ -311,9 +293,13 @@ func base58Decode(input string) ([]byte, error) {
 			carry >>= 8
 		}
 	}
+	// 数值为 0 时只保留前导零字节
+	if len(intData) == 1 && intData[0] == 0 {
+		intData = intData[:0]
+	}
 	// leading zeros
 	zeros := 0
-	for zeros < len(in) && in[zeros] == '1' {
+	for zeros < len(in) && in[zeros] == alphabet[0] {
 		zeros++
 	}
 	// little-endian -> big-endian
@@ -334,65 +320,6 @@ func bytesFromInts(a []int) []byte {
 	return b
 }
 
-// Bech32 (BIP-0173) 实现（简化版，仅用于校验）
-const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
-
-func bech32Polymod(values []byte) uint32 {
-	var chk uint32 = 1
-	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
-	for _, v := range values {
-		b := byte(chk >> 25)
-		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
-		for i := 0; i < 5; i++ {
-			if ((b >> uint(i)) & 1) == 1 {
-				chk ^= generator[i]
-			}
-		}
-	}
-	return chk
-}
-
-func bech32HrpExpand(hrp string) []byte {
-	ret := make([]byte, 0, len(hrp)*2+1)
-	for _, c := range hrp {
-		ret = append(ret, byte(c>>5))
-	}
-	ret = append(ret, 0)
-	for _, c := range hrp {
-		ret = append(ret, byte(c&31))
-	}
-	return ret
-}
-
-func bech32VerifyChecksum(hrp string, data []byte) bool {
-	exp := append(bech32HrpExpand(hrp), data...)
-	return bech32Polymod(exp) == 1
-}
-
-func bech32Decode(bech string) (string, []byte, error) {
-	bech = strings.ToLower(bech)
-	pos := strings.LastIndexByte(bech, '1')
-	if pos < 1 || pos+7 > len(bech) || len(bech) > 90 {
-		return "", nil, errors.New("invalid bech32 length")
-	}
-	hrp := bech[:pos]
-	data := bech[pos+1:]
-
-	values := make([]byte, len(data))
-	for i := 0; i < len(data); i++ {
-		d := strings.IndexByte(bech32Charset, data[i])
-		if d < 0 {
-			return "", nil, errors.New("invalid bech32 char")
-		}
-		values[i] = byte(d)
-	}
-	// checksum 6 个字符
-	if !bech32VerifyChecksum(hrp, values) {
-		return "", nil, errors.New("invalid bech32 checksum")
-	}
-	return hrp, values[:len(values)-6], nil
-}
-
 // CRC16-XMODEM（poly=0x1021, init=0x0000, refin=false, refout=false, xorout=0x0000）
 func crc16XModem(data []byte) []byte {
 	var crc uint16 = 0x0000
@@ -0,0 +1,107 @@
+package cryptocoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// -----------------------------
+// XRP：ripple 字母表 Base58Check，classic（r...）与 X-address（X... / T...）
+// -----------------------------
+
+var xrpAlphabet = []byte("rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+
+var (
+	xrpXAddrMainnet = []byte{0x05, 0x44} // X...
+	xrpXAddrTestnet = []byte{0x04, 0x93} // T...
+)
+
+// XRPAddress XRP 地址解析结果。X-address 把目标标签（destination tag）编进了地址本身。
+type XRPAddress struct {
+	Classic string  // classic 地址（r...）
+	Tag     *uint32 // 目标标签；classic 地址或 X-address 未带标签时为 nil
+	Testnet bool    // 仅 X-address 可区分测试网
+}
+
+// ParseXRPAddress 解析 classic 地址或 X-address（XLS-5d）。
+func ParseXRPAddress(address string) (XRPAddress, error) {
+	a := strings.TrimSpace(address)
+	raw, err := base58DecodeWith(a, xrpAlphabet)
+	if err != nil || len(raw) < 5 {
+		return XRPAddress{}, fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+	body, sum := raw[:len(raw)-4], raw[len(raw)-4:]
+	h1 := sha256.Sum256(body)
+	h2 := sha256.Sum256(h1[:])
+	if !bytes.Equal(sum, h2[:4]) {
+		return XRPAddress{}, fmt.Errorf("%w: %q", ErrAddressChecksum, address)
+	}
+
+	switch {
+	case len(body) == 21 && body[0] == 0x00:
+		return XRPAddress{Classic: a}, nil
+	case len(body) == 31:
+		var out XRPAddress
+		switch {
+		case bytes.Equal(body[:2], xrpXAddrMainnet):
+		case bytes.Equal(body[:2], xrpXAddrTestnet):
+			out.Testnet = true
+		default:
+			return XRPAddress{}, fmt.Errorf("%w: unknown X-address prefix", ErrAddressFormat)
+		}
+		out.Classic = xrpEncodeCheck(append([]byte{0x00}, body[2:22]...))
+		flag, tag := body[22], binary.LittleEndian.Uint64(body[23:31])
+		switch {
+		case flag == 0 && tag == 0:
+		case flag == 1 && tag <= 0xFFFFFFFF:
+			t := uint32(tag)
+			out.Tag = &t
+		default:
+			return XRPAddress{}, fmt.Errorf("%w: invalid X-address tag", ErrAddressFormat)
+		}
+		return out, nil
+	default:
+		return XRPAddress{}, fmt.Errorf("%w: %q", ErrAddressFormat, address)
+	}
+}
+
+// IsValidXRPAddress 校验 classic 地址或主网 X-address。
+func IsValidXRPAddress(address string) bool {
+	a, err := ParseXRPAddress(address)
+	return err == nil && !a.Testnet
+}
+
+// XRPXAddress 把 classic 地址与可选目标标签编码为 X-address，
+// 交易所充值地址用它可以避免用户漏填标签。
+func XRPXAddress(classic string, tag *uint32, testnet bool) (string, error) {
+	a, err := ParseXRPAddress(classic)
+	if err != nil {
+		return "", err
+	}
+	raw, _ := base58DecodeWith(a.Classic, xrpAlphabet)
+	body := make([]byte, 0, 31)
+	if testnet {
+		body = append(body, xrpXAddrTestnet...)
+	} else {
+		body = append(body, xrpXAddrMainnet...)
+	}
+	body = append(body, raw[1:21]...)
+	var tagBytes [8]byte
+	flag := byte(0)
+	if tag != nil {
+		flag = 1
+		binary.LittleEndian.PutUint64(tagBytes[:], uint64(*tag))
+	}
+	body = append(body, flag)
+	body = append(body, tagBytes[:]...)
+	return xrpEncodeCheck(body), nil
+}
+
+func xrpEncodeCheck(body []byte) string {
+	h1 := sha256.Sum256(body)
+	h2 := sha256.Sum256(h1[:])
+	return base58EncodeWith(append(append([]byte{}, body...), h2[:4]...), xrpAlphabet)
+}
@@ -0,0 +1,213 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// -----------------------------
+// 历史K线回补
+// -----------------------------
+//
+// Backfiller 按时间分页调用 /api/v3/klines，把 [start, end) 内已收盘的K线写入 KlineStore。
+// 再次运行时从存储中最后一根K线之后继续，因此中断后重跑即可续传。
+// 每页最多 1000 根、权重 2；每次请求前检查域名池记录的 1 分钟已用权重，超过预算时等到下一分钟。
+
+const (
+	klinesWeight         = 2
+	maxKlinesLimit       = 1000
+	defaultWeightBudget  = 5000 // 现货 REQUEST_WEIGHT 上限为每分钟 6000，留出余量给其它请求
+	weightWindowInterval = time.Minute
+)
+
+// KlineStore K线存储，按 symbol + interval 区分，K线按开盘时间递增追加。
+type KlineStore interface {
+	// Last 返回最后一根K线，没有数据时 ok 为 false。
+	Last(symbol, interval string) (k Kline, ok bool, err error)
+	// Append 追加K线，调用方保证开盘时间递增且晚于已存储的最后一根。
+	Append(symbol, interval string, klines []Kline) error
+}
+
+// BackfillProgress 每写入一页后回调的进度。
+type BackfillProgress struct {
+	Symbol   string
+	Interval string
+	Stored   int       // 本次运行已写入的K线数
+	Through  time.Time // 已写入的最后一根K线的开盘时间
+}
+
+// Backfiller 历史K线回补工具。
+type Backfiller struct {
+	Market       *MarketService
+	Store        KlineStore
+	Limit        int // 每页条数，默认且最大 1000
+	WeightBudget int // 每分钟最多使用的权重，默认 5000
+	OnProgress   func(BackfillProgress)
+}
+
+func NewBackfiller(m *MarketService, store KlineStore) *Backfiller {
+	if m == nil {
+		m = NewMarketService()
+	}
+	return &Backfiller{Market: m, Store: store, Limit: maxKlinesLimit, WeightBudget: defaultWeightBudget}
+}
+
+// Run 回补 [start, end) 内开盘且已收盘的K线，返回本次写入的数量。
+// end 为零值时回补到当前时间；存储中已有晚于 start 的数据时从最后一根之后继续。
+func (b *Backfiller) Run(ctx context.Context, symbol, interval string, start, end time.Time) (int, error) {
+	if _, err := ParseInterval(interval); err != nil {
+		return 0, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	limit := b.Limit
+	if limit <= 0 || limit > maxKlinesLimit {
+		limit = maxKlinesLimit
+	}
+
+	from := start
+	last, ok, err := b.Store.Last(symbol, interval)
+	if err != nil {
+		return 0, fmt.Errorf("binance: backfill %s %s: %w", symbol, interval, err)
+	}
+	if ok {
+		if next := time.UnixMilli(last.CloseTime + 1); next.After(from) {
+			from = next
+		}
+	}
+
+	stored := 0
+	for from.Before(end) {
+		if err := b.waitForWeight(ctx); err != nil {
+			return stored, err
+		}
+		// endTime 按开盘时间过滤且包含端点，减 1 毫秒使区间为左闭右开
+		klines, err := b.Market.GetKlinesBetween(ctx, symbol, interval, from, end.Add(-time.Millisecond), limit)
+		if err != nil {
+			return stored, fmt.Errorf("binance: backfill %s %s from %s: %w", symbol, interval, from.UTC().Format(time.RFC3339), err)
+		}
+		full := len(klines) == limit
+
+		// 未收盘的K线之后还会变化，留到下次运行
+		now := time.Now().UnixMilli()
+		for len(klines) > 0 && klines[len(klines)-1].CloseTime >= now {
+			klines = klines[:len(klines)-1]
+		}
+		if len(klines) == 0 {
+			break
+		}
+		if err := b.Store.Append(symbol, interval, klines); err != nil {
+			return stored, fmt.Errorf("binance: backfill %s %s: %w", symbol, interval, err)
+		}
+		stored += len(klines)
+		tail := klines[len(klines)-1]
+		from = time.UnixMilli(tail.CloseTime + 1)
+		if b.OnProgress != nil {
+			b.OnProgress(BackfillProgress{Symbol: symbol, Interval: interval, Stored: stored, Through: time.UnixMilli(tail.OpenTime)})
+		}
+		if !full {
+			break
+		}
+	}
+	return stored, nil
+}
+
+// waitForWeight 最近一分钟的已用权重超出预算时等到下一个窗口。
+func (b *Backfiller) waitForWeight(ctx context.Context) error {
+	budget := b.WeightBudget
+	if budget <= 0 {
+		budget = defaultWeightBudget
+	}
+	usage := poolOrDefault(b.Market.Pool).Usage()
+	used, ok := usage.UsedWeight["1m"]
+	if !ok || used+klinesWeight <= budget {
+		return nil
+	}
+	// 权重窗口按自然分钟重置
+	wait := time.Until(usage.UpdatedAt.Truncate(weightWindowInterval).Add(weightWindowInterval))
+	if wait <= 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// -----------------------------
+// K线周期与缺口检测
+// -----------------------------
+
+// Interval K线周期，Months 不为零时表示按自然月计（"1M"），否则为固定时长。
+type Interval struct {
+	Duration time.Duration
+	Months   int
+}
+
+// ParseInterval 解析 Binance 的K线周期，如 "1s"、"15m"、"4h"、"1d"、"1w"、"1M"。
+func ParseInterval(s string) (Interval, error) {
+	if len(s) < 2 {
+		return Interval{}, fmt.Errorf("binance: invalid kline interval %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return Interval{}, fmt.Errorf("binance: invalid kline interval %q", s)
+	}
+	unit := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	switch u := s[len(s)-1]; {
+	case u == 'M':
+		return Interval{Months: n}, nil
+	case unit[u] > 0:
+		return Interval{Duration: time.Duration(n) * unit[u]}, nil
+	}
+	return Interval{}, fmt.Errorf("binance: invalid kline interval %q", s)
+}
+
+// Next 返回 openTime 之后下一根K线的开盘时间（UTC）。
+func (iv Interval) Next(openTime time.Time) time.Time {
+	if iv.Months > 0 {
+		return openTime.UTC().AddDate(0, iv.Months, 0)
+	}
+	return openTime.Add(iv.Duration)
+}
+
+// Gap 缺失的K线区间，From 为第一根缺失K线的开盘时间，To 为缺口之后第一根存在的K线的开盘时间。
+type Gap struct {
+	From  time.Time
+	To    time.Time
+	Count int // 缺失的K线数
+}
+
+// DetectGaps 检查按开盘时间递增的K线是否连续。
+// Binance 在系统维护期间确实没有K线，缺口不一定意味着数据损坏，可再次请求对应区间确认。
+func DetectGaps(klines []Kline, interval string) ([]Gap, error) {
+	iv, err := ParseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	var gaps []Gap
+	for i := 1; i < len(klines); i++ {
+		prev, cur := time.UnixMilli(klines[i-1].OpenTime), time.UnixMilli(klines[i].OpenTime)
+		next := iv.Next(prev)
+		if !next.Before(cur) {
+			continue
+		}
+		g := Gap{From: next, To: cur}
+		if iv.Months > 0 {
+			for t := next; t.Before(cur); t = iv.Next(t) {
+				g.Count++
+			}
+		} else {
+			g.Count = int((cur.Sub(next) + iv.Duration - 1) / iv.Duration)
+		}
+		gaps = append(gaps, g)
+	}
+	return gaps, nil
+}
@@ -1,9 +1,11 @@
 package binance
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
-	"time"
+	"net/url"
 
 	"github.com/goccy/go-json"
 	"github.com/rs/zerolog/log"
@@ -31,76 +33,66 @@ var apiURLs = []string{
 	baseURL7,
 }
 
-// 封装了带超时功能的HTTP客户端
-var httpClient = &http.Client{
-	Timeout: 5 * time.Second,
+// marketDataURL 只提供公开行情，不能用于带 API Key 的请求
+const marketDataURL = baseURL7
+
+// request 一次 REST 调用。参数一律放在查询串中（Binance 的 POST / DELETE 同样接受）。
+type request struct {
+	method   string
+	path     string
+	params   url.Values
+	rawQuery string // 已签名的查询串，设置后忽略 params
+	header   http.Header
 }
 
-// 创建请求的函数
-func createGetRequest(url string) (*http.Request, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+func (r request) requestURI() string {
+	query := r.rawQuery
+	if query == "" {
+		query = r.params.Encode()
+	}
+	if query == "" {
+		return r.path
 	}
-	return req, nil
+	return r.path + "?" + query
 }
 
-// 执行请求并处理失败的函数
-func doRequestWithFallback(req *http.Request) (*http.Response, error) {
-	var lastErr error
-	for _, baseURL := range apiURLs {
-		// 更新请求URL
-		req.URL, _ = req.URL.Parse(baseURL + req.URL.RequestURI())
-		// 执行请求
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			lastErr = err
-			log.Error().Str("url", baseURL).Err(err).Msg("Error accessing URL")
-			continue
-		}
-		// 检查返回状态
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, baseURL)
-			log.Error().Str("url", baseURL).Err(lastErr).Msg("Error from URL")
-			continue
-		}
-		return resp, nil
+// readAPIError 读取并关闭非 200 响应，响应体不是 Binance 错误格式时以状态文本作为 Msg。
+func readAPIError(resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(body, apiErr) != nil || apiErr.Msg == "" {
+		apiErr.Msg = http.StatusText(resp.StatusCode)
 	}
-	return nil, fmt.Errorf("failed to get data from all API endpoints: %v", lastErr)
+	return apiErr
 }
 
-// 解析返回数据的函数
-func parseResponse(resp *http.Response) (interface{}, error) {
+// do 执行请求并把 JSON 响应解码到 out。
+func (p *EndpointPool) do(ctx context.Context, r request, out any) error {
+	resp, err := p.send(ctx, r)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
-	var result interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("binance: decode %s: %w", r.path, err)
 	}
-	return result, nil
+	return nil
+}
+
+// get 请求公开接口。
+func (p *EndpointPool) get(ctx context.Context, path string, params url.Values, out any) error {
+	return p.do(ctx, request{method: http.MethodGet, path: path, params: params}, out)
 }
 
 // GetApi Get请求流程
+//
+// Deprecated: 错误只记录日志、返回 nil，调用方只能对 map 做类型断言。请使用 MarketService 的类型化方法。
 func GetApi(query string) interface{} {
-	// 创建请求
-	req, err := createGetRequest(query)
-	if err != nil {
-		log.Error().Err(err).Msg("Error creating request")
-		return nil
-	}
-
-	// 执行请求并处理失败
-	resp, err := doRequestWithFallback(req)
-	if err != nil {
+	var result interface{}
+	if err := DefaultPool.get(context.Background(), query, nil, &result); err != nil {
 		log.Error().Err(err).Msg("Error executing request")
 		return nil
 	}
-
-	// 解析响应
-	result, err := parseResponse(resp)
-	if err != nil {
-		log.Error().Err(err).Msg("Error parsing response")
-		return nil
-	}
-
 	return result
 }
@@ -0,0 +1,247 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// -----------------------------
+// REST 域名池：健康检查、熔断与封禁处理
+// -----------------------------
+//
+// 每次请求先发往上一次成功的域名（粘滞），失败后按平均延迟依次尝试其余域名。
+// 连续失败 FailureThreshold 次的域名熔断 Cooldown 时长，期间只在其它域名都不可用时才会尝试；
+// 冷却结束后放行一次试探请求，成功即恢复。
+//
+// 418 / 429 是按 IP 计的封禁，换域名无效且会延长封禁时间，因此整个池在 Retry-After 之前直接返回错误。
+
+const (
+	defaultFailureThreshold = 3
+	defaultCooldown         = 30 * time.Second
+	defaultBanDuration      = time.Minute // 响应未带 Retry-After 时的封禁时长
+	latencyWeight           = 0.2         // 延迟 EWMA 中新样本的权重
+)
+
+// EndpointPool 一组可互换的 REST 域名及其共享的 http.Client，并发安全。
+type EndpointPool struct {
+	Client           *http.Client
+	FailureThreshold int           // 连续失败多少次后熔断，默认 3
+	Cooldown         time.Duration // 熔断时长，默认 30 秒
+
+	mu          sync.Mutex
+	endpoints   []*endpoint
+	preferred   *endpoint
+	bannedUntil time.Time
+	banStatus   int
+	usage       RateLimitUsage
+}
+
+type endpoint struct {
+	baseURL    string
+	publicOnly bool          // 只提供公开行情，不能用于带 API Key 的请求
+	latency    time.Duration // 成功请求的延迟 EWMA，0 表示尚未测量
+	failures   int           // 连续失败次数
+	openUntil  time.Time     // 熔断截止时间
+}
+
+// EndpointStats 域名的当前状态，用于监控。
+type EndpointStats struct {
+	BaseURL   string
+	Latency   time.Duration
+	Failures  int
+	OpenUntil time.Time
+	Preferred bool
+}
+
+// NewEndpointPool 创建域名池；client 为 nil 时使用 5 秒超时的默认客户端，baseURLs 为空时使用 Binance 官方域名。
+// 测试时可传入 httptest 服务的地址。
+func NewEndpointPool(client *http.Client, baseURLs ...string) *EndpointPool {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	if len(baseURLs) == 0 {
+		baseURLs = apiURLs
+	}
+	p := &EndpointPool{Client: client, FailureThreshold: defaultFailureThreshold, Cooldown: defaultCooldown}
+	for _, u := range baseURLs {
+		p.endpoints = append(p.endpoints, &endpoint{baseURL: u, publicOnly: u == marketDataURL})
+	}
+	p.preferred = p.endpoints[0]
+	return p
+}
+
+// DefaultPool 未指定 Pool 的服务共用的域名池。
+var DefaultPool = NewEndpointPool(nil)
+
+func poolOrDefault(p *EndpointPool) *EndpointPool {
+	if p == nil {
+		return DefaultPool
+	}
+	return p
+}
+
+// Stats 返回各域名的状态。
+func (p *EndpointPool) Stats() []EndpointStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]EndpointStats, len(p.endpoints))
+	for i, e := range p.endpoints {
+		out[i] = EndpointStats{BaseURL: e.baseURL, Latency: e.latency, Failures: e.failures, OpenUntil: e.openUntil, Preferred: e == p.preferred}
+	}
+	return out
+}
+
+// candidates 本次请求的尝试顺序：粘滞域名、其余可用域名（按延迟）、熔断中的域名（按恢复时间）。
+func (p *EndpointPool) candidates(withKey bool) []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	var healthy, open []*endpoint
+	for _, e := range p.endpoints {
+		switch {
+		case withKey && e.publicOnly:
+		case now.Before(e.openUntil):
+			open = append(open, e)
+		case e != p.preferred:
+			healthy = append(healthy, e)
+		}
+	}
+	// 未测量的域名排在已测量的之后，保持配置顺序
+	slices.SortStableFunc(healthy, func(a, b *endpoint) int {
+		switch {
+		case a.latency == b.latency:
+			return 0
+		case a.latency == 0:
+			return 1
+		case b.latency == 0:
+			return -1
+		}
+		return int(a.latency - b.latency)
+	})
+	slices.SortStableFunc(open, func(a, b *endpoint) int { return a.openUntil.Compare(b.openUntil) })
+
+	out := make([]*endpoint, 0, len(p.endpoints))
+	if pe := p.preferred; !(withKey && pe.publicOnly) && !now.Before(pe.openUntil) {
+		out = append(out, pe)
+	}
+	return append(append(out, healthy...), open...)
+}
+
+func (p *EndpointPool) markSuccess(e *endpoint, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+	}
+	e.failures = 0
+	e.openUntil = time.Time{}
+	p.preferred = e
+}
+
+func (p *EndpointPool) markFailure(e *endpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.failures++
+	threshold, cooldown := p.FailureThreshold, p.Cooldown
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	if e.failures >= threshold {
+		e.openUntil = time.Now().Add(cooldown)
+	}
+}
+
+// ban 记录 418 / 429 封禁，返回剩余时长。
+func (p *EndpointPool) ban(status int, h http.Header) time.Duration {
+	d := defaultBanDuration
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs >= 0 {
+		d = time.Duration(secs) * time.Second
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.bannedUntil) {
+		p.bannedUntil, p.banStatus = until, status
+	}
+	return d
+}
+
+// banned 封禁中时返回对应的错误。
+func (p *EndpointPool) banned() *APIError {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	remaining := time.Until(p.bannedUntil)
+	if remaining <= 0 {
+		return nil
+	}
+	return &APIError{StatusCode: p.banStatus, Msg: "rate limited, waiting for Retry-After", RetryAfter: remaining}
+}
+
+// send 发送请求并处理失败：网络错误与 5xx 换下一个域名重试，
+// 4xx 属于请求本身的问题（参数错误、交易对不存在等），换域名也无济于事，直接返回 *APIError。
+// 只有 GET 会切换域名：下单、撤单在超时或 5xx 时可能已被执行，重发会造成重复操作，交由调用方查询确认。
+func (p *EndpointPool) send(ctx context.Context, r request) (*http.Response, error) {
+	if err := p.banned(); err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for _, e := range p.candidates(r.header.Get(apiKeyHeader) != "") {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, r.method, e.baseURL+r.requestURI(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range r.header {
+			req.Header[k] = v
+		}
+		start := time.Now()
+		resp, err := p.Client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			p.markFailure(e)
+			lastErr = err
+			log.Warn().Str("url", e.baseURL).Err(err).Msg("Error accessing URL")
+		} else {
+			p.recordUsage(resp.Header)
+			switch code := resp.StatusCode; {
+			case code == http.StatusOK:
+				p.markSuccess(e, time.Since(start))
+				return resp, nil
+			case code == http.StatusTeapot || code == http.StatusTooManyRequests:
+				p.markSuccess(e, time.Since(start))
+				apiErr := readAPIError(resp)
+				apiErr.RetryAfter = p.ban(code, resp.Header)
+				return nil, apiErr
+			case code >= 400 && code < 500:
+				p.markSuccess(e, time.Since(start))
+				return nil, readAPIError(resp)
+			default:
+				p.markFailure(e)
+				lastErr = readAPIError(resp)
+				log.Warn().Str("url", e.baseURL).Err(lastErr).Msg("Error from URL")
+			}
+		}
+		if r.method != http.MethodGet {
+			return nil, lastErr
+		}
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("binance: no endpoint available for %s", r.path)
+	}
+	return nil, fmt.Errorf("failed to get data from all API endpoints: %w", lastErr)
+}
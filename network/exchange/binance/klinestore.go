@@ -0,0 +1,223 @@
+package binance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// csvHeader 与 data.binance.vision 公开数据的列顺序一致（去掉最后的 ignore 列）。
+var csvHeader = []string{
+	"open_time", "open", "high", "low", "close", "volume",
+	"close_time", "quote_volume", "count", "taker_buy_volume", "taker_buy_quote_volume",
+}
+
+// CSVStore 把K线按 symbol + interval 追加到 Dir 下的 CSV 文件中，如 BTCUSDT-1m.csv。
+// 同一进程内并发安全；不支持多个进程同时写同一文件。
+type CSVStore struct {
+	Dir string
+	mu  sync.Mutex
+}
+
+func NewCSVStore(dir string) (*CSVStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("binance: create kline dir: %w", err)
+	}
+	return &CSVStore{Dir: dir}, nil
+}
+
+// Path 返回 symbol + interval 对应的文件路径。
+// 月线 "1M" 写作 "1mo"，避免在不区分大小写的文件系统上与分钟线 "1m" 冲突。
+func (s *CSVStore) Path(symbol, interval string) string {
+	if strings.HasSuffix(interval, "M") {
+		interval = strings.TrimSuffix(interval, "M") + "mo"
+	}
+	return filepath.Join(s.Dir, strings.ToUpper(symbol)+"-"+interval+".csv")
+}
+
+// completeSize 文件中完整记录的字节数，即最后一个换行符之后的位置。
+// 进程在 Append 中途退出时，文件末尾会留下没有换行符的半行，读取时忽略、下次追加前截掉。
+func completeSize(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	const chunk = 4096
+	buf := make([]byte, chunk)
+	for end := info.Size(); end > 0; {
+		start := max(end-chunk, 0)
+		n, err := f.ReadAt(buf[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// Last 只读取文件末尾来获取最后一根K线，大文件也不必全部解析；末尾不完整的半行被忽略。
+func (s *CSVStore) Last(symbol, interval string) (Kline, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.Path(symbol, interval))
+	if errors.Is(err, os.ErrNotExist) {
+		return Kline{}, false, nil
+	}
+	if err != nil {
+		return Kline{}, false, err
+	}
+	defer f.Close()
+
+	size, err := completeSize(f)
+	if err != nil || size == 0 {
+		return Kline{}, false, err
+	}
+	const tailSize = 4096
+	offset := max(size-tailSize, 0)
+	buf := make([]byte, size-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+		return Kline{}, false, err
+	}
+	buf = bytes.TrimRight(buf, "\n")
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+		buf = buf[i+1:]
+	} else if offset > 0 {
+		return Kline{}, false, fmt.Errorf("binance: %s: last line longer than %d bytes", f.Name(), tailSize)
+	}
+	record, err := csv.NewReader(bytes.NewReader(buf)).Read()
+	if err != nil {
+		return Kline{}, false, fmt.Errorf("binance: %s: %w", f.Name(), err)
+	}
+	if record[0] == csvHeader[0] {
+		return Kline{}, false, nil
+	}
+	k, err := parseKlineRecord(record)
+	if err != nil {
+		return Kline{}, false, fmt.Errorf("binance: %s: %w", f.Name(), err)
+	}
+	return k, true, nil
+}
+
+// Append 追加K线，新文件会先写表头；上次写入中断留下的半行会先被截掉。
+func (s *CSVStore) Append(symbol, interval string, klines []Kline) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.Path(symbol, interval), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	size, err := completeSize(f)
+	if err == nil {
+		err = f.Truncate(size)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w := csv.NewWriter(f)
+	if size == 0 {
+		_ = w.Write(csvHeader)
+	}
+	for _, k := range klines {
+		_ = w.Write(klineRecord(k))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load 读取全部K线，末尾不完整的半行被忽略。
+func (s *CSVStore) Load(symbol, interval string) ([]Kline, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.Path(symbol, interval))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	size, err := completeSize(f)
+	if err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(io.NewSectionReader(f, 0, size))
+	r.FieldsPerRecord = len(csvHeader)
+	r.ReuseRecord = true
+	var klines []Kline
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return klines, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("binance: %s: %w", f.Name(), err)
+		}
+		if line == 1 && record[0] == csvHeader[0] {
+			continue
+		}
+		k, err := parseKlineRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("binance: %s line %d: %w", f.Name(), line, err)
+		}
+		klines = append(klines, k)
+	}
+}
+
+// Gaps 检测已存储K线中的缺口。
+func (s *CSVStore) Gaps(symbol, interval string) ([]Gap, error) {
+	klines, err := s.Load(symbol, interval)
+	if err != nil {
+		return nil, err
+	}
+	return DetectGaps(klines, interval)
+}
+
+func klineRecord(k Kline) []string {
+	return []string{
+		strconv.FormatInt(k.OpenTime, 10), k.Open.String(), k.High.String(), k.Low.String(), k.Close.String(), k.Volume.String(),
+		strconv.FormatInt(k.CloseTime, 10), k.QuoteVolume.String(), strconv.FormatInt(k.Trades, 10),
+		k.TakerBuyVolume.String(), k.TakerBuyQuoteVolume.String(),
+	}
+}
+
+func parseKlineRecord(record []string) (Kline, error) {
+	if len(record) < len(csvHeader) {
+		return Kline{}, fmt.Errorf("expected %d fields, got %d", len(csvHeader), len(record))
+	}
+	var k Kline
+	var err error
+	ints := map[int]*int64{0: &k.OpenTime, 6: &k.CloseTime, 8: &k.Trades}
+	decs := map[int]*decimal.Decimal{
+		1: &k.Open, 2: &k.High, 3: &k.Low, 4: &k.Close, 5: &k.Volume,
+		7: &k.QuoteVolume, 9: &k.TakerBuyVolume, 10: &k.TakerBuyQuoteVolume,
+	}
+	for i, p := range ints {
+		if *p, err = strconv.ParseInt(record[i], 10, 64); err != nil {
+			return Kline{}, fmt.Errorf("%s: %w", csvHeader[i], err)
+		}
+	}
+	for i, p := range decs {
+		if *p, err = decimal.NewFromString(record[i]); err != nil {
+			return Kline{}, fmt.Errorf("%s: %w", csvHeader[i], err)
+		}
+	}
+	return k, nil
+}
@@ -1,158 +1,193 @@
 package binance
 
 import (
-	"fmt"
+	"context"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/goccy/go-json"
 )
 
-type MarketService struct{}
+// MarketService 公开行情接口。
+type MarketService struct {
+	Pool *EndpointPool // 为 nil 时使用 DefaultPool
+}
 
 func NewMarketService() *MarketService {
-	return &MarketService{}
+	return &MarketService{Pool: DefaultPool}
+}
+
+func (m *MarketService) get(ctx context.Context, path string, params url.Values, out any) error {
+	return poolOrDefault(m.Pool).get(ctx, path, params, out)
 }
 
 // GetPing 请求 Binance API 进行连接测试
-func (m *MarketService) GetPing() interface{} {
-	query := "/api/v3/ping"
-	return GetApi(query)
+func (m *MarketService) GetPing(ctx context.Context) error {
+	var resp struct{}
+	return m.get(ctx, "/api/v3/ping", nil, &resp)
 }
 
 // GetServerTime 请求 Binance API 获取服务器时间
-func (m *MarketService) GetServerTime() interface{} {
-	query := "/api/v3/time"
-	return GetApi(query)
+func (m *MarketService) GetServerTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := m.get(ctx, "/api/v3/time", nil, &resp); err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(resp.ServerTime), nil
 }
 
 // GetExchangeInfo 请求 Binance API 获取交易所信息
-func (m *MarketService) GetExchangeInfo() interface{} {
-	query := "/api/v3/exchangeInfo"
-	return GetApi(query)
+// symbols 为空时返回全部交易对（响应较大，约 20 权重）
+func (m *MarketService) GetExchangeInfo(ctx context.Context, symbols ...string) (ExchangeInfo, error) {
+	var info ExchangeInfo
+	err := m.get(ctx, "/api/v3/exchangeInfo", symbolsParams(symbols), &info)
+	return info, err
 }
 
 // GetDepth 请求 Binance API 获取订单簿
-func (m *MarketService) GetDepth(symbol string) interface{} {
-	query := fmt.Sprintf("/api/v3/depth?symbol=%s", symbol)
-	return GetApi(query)
+// limit 默认 100，最大 5000；0 表示使用默认值
+func (m *MarketService) GetDepth(ctx context.Context, symbol string, limit int) (Depth, error) {
+	var depth Depth
+	err := m.get(ctx, "/api/v3/depth", symbolLimitParams(symbol, limit), &depth)
+	return depth, err
 }
 
 // GetTrades 请求 Binance API 获取最近的交易列表
-func (m *MarketService) GetTrades(symbol string, limit int) interface{} {
-	query := fmt.Sprintf("/api/v3/trades?symbol=%s&limit=%d", symbol, limit)
-	return GetApi(query)
+func (m *MarketService) GetTrades(ctx context.Context, symbol string, limit int) ([]Trade, error) {
+	var trades []Trade
+	err := m.get(ctx, "/api/v3/trades", symbolLimitParams(symbol, limit), &trades)
+	return trades, err
 }
 
 // GetHistoricalTrades 请求 Binance API 获取旧的交易记录
-func (m *MarketService) GetHistoricalTrades(symbol string, limit int) interface{} {
-	query := fmt.Sprintf("/api/v3/historicalTrades?symbol=%s&limit=%d", symbol, limit)
-	return GetApi(query)
+// fromID 为起始成交 ID，0 表示返回最近的成交
+func (m *MarketService) GetHistoricalTrades(ctx context.Context, symbol string, limit int, fromID int64) ([]Trade, error) {
+	params := symbolLimitParams(symbol, limit)
+	if fromID > 0 {
+		params.Set("fromId", strconv.FormatInt(fromID, 10))
+	}
+	var trades []Trade
+	err := m.get(ctx, "/api/v3/historicalTrades", params, &trades)
+	return trades, err
 }
 
 // GetAggTrades 请求 Binance API 获取压缩/聚合交易记录
-func (m *MarketService) GetAggTrades(symbol string, limit int) interface{} {
-	query := fmt.Sprintf("/api/v3/aggTrades?symbol=%s&limit=%d", symbol, limit)
-	return GetApi(query)
+func (m *MarketService) GetAggTrades(ctx context.Context, symbol string, limit int) ([]AggTrade, error) {
+	var trades []AggTrade
+	err := m.get(ctx, "/api/v3/aggTrades", symbolLimitParams(symbol, limit), &trades)
+	return trades, err
 }
 
 // GetKlines 请求 Binance API 获取K线/蜡烛图数据
-func (m *MarketService) GetKlines(symbol, interval string) interface{} {
-	query := fmt.Sprintf("/api/v3/klines?symbol=%s&interval=%s", symbol, interval)
-	return GetApi(query)
+// interval 如 "1m"、"1h"、"1d"；limit 默认 500，最大 1000
+func (m *MarketService) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	return m.klines(ctx, "/api/v3/klines", symbol, interval, limit)
 }
 
 // GetUIKlines 请求 Binance API 获取UIK线数据
-func (m *MarketService) GetUIKlines(symbol, interval string) interface{} {
-	query := fmt.Sprintf("/api/v3/uiKlines?symbol=%s&interval=%s", symbol, interval)
-	return GetApi(query)
+func (m *MarketService) GetUIKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	return m.klines(ctx, "/api/v3/uiKlines", symbol, interval, limit)
+}
+
+// GetKlinesBetween 请求 Binance API 获取 [start, end] 内开盘的K线，从 start 起按时间正序最多返回 limit 根
+// start、end 为零值时不限制；翻页时把下一页的 start 设为上一页最后一根的 CloseTime 之后
+func (m *MarketService) GetKlinesBetween(ctx context.Context, symbol, interval string, start, end time.Time, limit int) ([]Kline, error) {
+	params := symbolLimitParams(symbol, limit)
+	params.Set("interval", interval)
+	if !start.IsZero() {
+		params.Set("startTime", strconv.FormatInt(start.UnixMilli(), 10))
+	}
+	if !end.IsZero() {
+		params.Set("endTime", strconv.FormatInt(end.UnixMilli(), 10))
+	}
+	var klines []Kline
+	err := m.get(ctx, "/api/v3/klines", params, &klines)
+	return klines, err
+}
+
+func (m *MarketService) klines(ctx context.Context, path, symbol, interval string, limit int) ([]Kline, error) {
+	params := symbolLimitParams(symbol, limit)
+	params.Set("interval", interval)
+	var klines []Kline
+	err := m.get(ctx, path, params, &klines)
+	return klines, err
 }
 
 // GetAvgPrice 请求 Binance API 获取当前平均价格
-func (m *MarketService) GetAvgPrice(symbol string) interface{} {
-	query := fmt.Sprintf("/api/v3/avgPrice?symbol=%s", symbol)
-	return GetApi(query)
+func (m *MarketService) GetAvgPrice(ctx context.Context, symbol string) (AvgPrice, error) {
+	var avg AvgPrice
+	err := m.get(ctx, "/api/v3/avgPrice", url.Values{"symbol": {symbol}}, &avg)
+	return avg, err
 }
 
 // GetTicker24Hr 请求 Binance API 获取24小时价格变动情况
-// symbols 是一个包含多个交易对的字符串数组，例如 ["BTCUSDT", "BNBBTC"]
-// 返回结果是一个 interface{} 类型，包含了各个交易对的24小时价格变动数据
-// 返回的 JSON 数据结构如下:
-//
-//	[{
-//	  "symbol": "BNBBTC",                 // 交易对
-//	  "priceChange": "-0.00010200",       // 价格变动
-//	  "priceChangePercent": "-1.152",     // 价格变动百分比
-//	  "weightedAvgPrice": "0.00877364",   // 加权平均价格
-//	  "prevClosePrice": "0.00885700",     // 前一次收盘价
-//	  "lastPrice": "0.00875500",          // 最新价格
-//	  "lastQty": "3.92000000",            // 最新成交量
-//	  "bidPrice": "0.00875500",           // 买入价格
-//	  "bidQty": "20.61500000",            // 买入数量
-//	  "askPrice": "0.00875600",           // 卖出价格
-//	  "askQty": "10.44800000",            // 卖出数量
-//	  "openPrice": "0.00885700",          // 开盘价
-//	  "highPrice": "0.00889800",          // 最高价
-//	  "lowPrice": "0.00868500",           // 最低价
-//	  "volume": "48607.13000000",         // 成交量
-//	  "quoteVolume": "426.46130696",      // 成交额
-//	  "openTime": 1721647533545,          // 开盘时间（Unix毫秒时间戳）
-//	  "closeTime": 1721733933545,         // 收盘时间（Unix毫秒时间戳）
-//	  "firstId": 251266985,               // 首次成交ID
-//	  "lastId": 251440381,                // 最后成交ID
-//	  "count": 173397                     // 成交笔数
-//	},]
-func (m *MarketService) GetTicker24Hr(symbols []string, dataType string) interface{} {
-	symbolsParam, _ := json.Marshal(symbols)
-	query := fmt.Sprintf("/api/v3/ticker/24hr?symbols=%s&type=%s", symbolsParam, dataType)
-	return GetApi(query)
+// symbols 是一个包含多个交易对的字符串数组，例如 ["BTCUSDT", "BNBBTC"]，为空时返回全部交易对
+// dataType 为 TickerFull 或 TickerMini，空字符串等同 TickerFull
+func (m *MarketService) GetTicker24Hr(ctx context.Context, symbols []string, dataType string) ([]TickerStats, error) {
+	params := symbolsParams(symbols)
+	setIfNotEmpty(params, "type", dataType)
+	var stats []TickerStats
+	err := m.get(ctx, "/api/v3/ticker/24hr", params, &stats)
+	return stats, err
 }
 
 // GetTickerTradingDay 请求 Binance API 获取交易日价格变动情况
-func (m *MarketService) GetTickerTradingDay(symbols []string) interface{} {
-	symbolsParam, _ := json.Marshal(symbols)
-	query := fmt.Sprintf("/api/v3/ticker/tradingDay?symbols=%s", symbolsParam)
-	return GetApi(query)
+func (m *MarketService) GetTickerTradingDay(ctx context.Context, symbols []string) ([]TickerStats, error) {
+	var stats []TickerStats
+	err := m.get(ctx, "/api/v3/ticker/tradingDay", symbolsParams(symbols), &stats)
+	return stats, err
 }
 
 // GetTickerPrice 请求 Binance API 获取Symbol价格
-func (m *MarketService) GetTickerPrice(symbols []string) interface{} {
-	symbolsParam, _ := json.Marshal(symbols)
-	query := fmt.Sprintf("/api/v3/ticker/price?symbols=%s", symbolsParam)
-	return GetApi(query)
+func (m *MarketService) GetTickerPrice(ctx context.Context, symbols []string) ([]TickerPrice, error) {
+	var prices []TickerPrice
+	err := m.get(ctx, "/api/v3/ticker/price", symbolsParams(symbols), &prices)
+	return prices, err
 }
 
 // GetTickerBookTicker 请求 Binance API 获取Symbol Order Book Ticker
-func (m *MarketService) GetTickerBookTicker(symbols []string) interface{} {
-	symbolsParam, _ := json.Marshal(symbols)
-	query := fmt.Sprintf("/api/v3/ticker/bookTicker?symbols=%s", symbolsParam)
-	return GetApi(query)
+func (m *MarketService) GetTickerBookTicker(ctx context.Context, symbols []string) ([]BookTicker, error) {
+	var tickers []BookTicker
+	err := m.get(ctx, "/api/v3/ticker/bookTicker", symbolsParams(symbols), &tickers)
+	return tickers, err
 }
 
 // GetTicker 请求 Binance API 获取滚动窗口价格变化统计数据
 // symbols: 交易对列表，例如 ["BTCUSDT", "BNBUSDT"]
 // windowSize: 时间窗口大小，支持的值有 "1m", "2m", ... "59m"（分钟）, "1h", "2h", ... "23h"（小时）, "1d", ... "7d"（天）
-// type: 支持的值有 "FULL" 或 "MINI"。如果未提供，默认为 "FULL"
-// 返回字段：
-//   - symbol: 交易对
-//   - priceChange: 价格变化
-//   - priceChangePercent: 价格变化百分比
-//   - weightedAvgPrice: 加权平均价格
-//   - prevClosePrice: 前收盘价
-//   - lastPrice: 最新价格
-//   - lastQty: 最新成交量
-//   - bidPrice: 买方出价
-//   - askPrice: 卖方出价
-//   - openPrice: 开盘价
-//   - highPrice: 最高价
-//   - lowPrice: 最低价
-//   - volume: 成交量
-//   - quoteVolume: 成交额
-//   - openTime: 开始时间
-//   - closeTime: 结束时间
-//   - firstId: 首次成交ID
-//   - lastId: 最后成交ID
-//   - count: 成交次数
-func (m *MarketService) GetTicker(symbols []string, windowSize string, dataType string) interface{} {
-	symbolsParam, _ := json.Marshal(symbols)
-	query := fmt.Sprintf("/api/v3/ticker?symbols=%s&windowSize=%s&type=%s", symbolsParam, windowSize, dataType)
-	return GetApi(query)
+// dataType: 支持的值有 TickerFull 或 TickerMini。如果未提供，默认为 TickerFull
+func (m *MarketService) GetTicker(ctx context.Context, symbols []string, windowSize string, dataType string) ([]TickerStats, error) {
+	params := symbolsParams(symbols)
+	setIfNotEmpty(params, "windowSize", windowSize)
+	setIfNotEmpty(params, "type", dataType)
+	var stats []TickerStats
+	err := m.get(ctx, "/api/v3/ticker", params, &stats)
+	return stats, err
+}
+
+func symbolLimitParams(symbol string, limit int) url.Values {
+	params := url.Values{"symbol": {symbol}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	return params
+}
+
+// symbolsParams symbols 参数为 JSON 数组，如 ["BTCUSDT","BNBUSDT"]
+func symbolsParams(symbols []string) url.Values {
+	params := url.Values{}
+	if len(symbols) > 0 {
+		b, _ := json.Marshal(symbols)
+		params.Set("symbols", string(b))
+	}
+	return params
+}
+
+func setIfNotEmpty(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
 }
@@ -0,0 +1,183 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+// -----------------------------
+// 本地订单簿：REST 快照 + 增量深度
+// -----------------------------
+//
+// 按 Binance 文档的同步流程维护：
+//  1. 订阅 <symbol>@depth，收到第一条增量后拉取 REST 快照
+//  2. 快照 lastUpdateId 小于该增量的 U 时重新拉取
+//  3. 丢弃 u <= lastUpdateId 的增量；第一条应用的增量须满足 U <= lastUpdateId+1 <= u
+//  4. 之后每条增量的 U 必须等于上一条的 u+1，否则视为丢包，重新拉取快照
+//
+// 断线重连后的第一条增量必然不连续，因此会自动重新同步。
+
+// DepthSnapshotFunc 拉取订单簿快照，MarketService.GetDepth 即满足该签名。
+type DepthSnapshotFunc func(ctx context.Context, symbol string, limit int) (Depth, error)
+
+// snapshotRetries 快照落后于增量时的重试次数。
+const snapshotRetries = 3
+
+// OrderBook 单个交易对的本地订单簿，并发安全。
+type OrderBook struct {
+	Symbol string
+
+	snapshot DepthSnapshotFunc
+	limit    int
+
+	mu           sync.RWMutex
+	synced       bool
+	lastUpdateID int64
+	bids         []PriceLevel // 价格从高到低
+	asks         []PriceLevel // 价格从低到高
+}
+
+// NewOrderBook 创建订单簿；limit 为快照档数，0 时取 1000（接口上限 5000，越大权重越高）。
+func NewOrderBook(symbol string, snapshot DepthSnapshotFunc, limit int) *OrderBook {
+	if limit <= 0 {
+		limit = 1000
+	}
+	return &OrderBook{Symbol: symbol, snapshot: snapshot, limit: limit}
+}
+
+// Run 订阅 100ms 增量深度并持续维护订单簿，阻塞直到 ctx 结束。同步失败通过 c.OnError 报告，
+// 下一条增量到达时重试。
+func (b *OrderBook) Run(ctx context.Context, c *StreamClient) error {
+	return c.Run(ctx, []string{FastDepthStream(b.Symbol)}, func(msg StreamMessage) {
+		var ev DepthEvent
+		if err := json.Unmarshal(msg.Data, &ev); err != nil {
+			c.reportError(fmt.Errorf("binance order book %s: %w", b.Symbol, err))
+			return
+		}
+		if err := b.Apply(ctx, ev); err != nil {
+			c.reportError(err)
+		}
+	})
+}
+
+// Apply 应用一条增量深度，必要时先拉取快照。须按推送顺序串行调用；返回错误时订单簿处于未同步状态。
+func (b *OrderBook) Apply(ctx context.Context, ev DepthEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.synced && ev.FirstUpdateID > b.lastUpdateID+1 {
+		b.synced = false
+	}
+	if !b.synced {
+		if err := b.resync(ctx, ev); err != nil {
+			return err
+		}
+	}
+	if ev.FinalUpdateID <= b.lastUpdateID {
+		return nil
+	}
+	for _, l := range ev.Bids {
+		b.bids = upsertLevel(b.bids, l, true)
+	}
+	for _, l := range ev.Asks {
+		b.asks = upsertLevel(b.asks, l, false)
+	}
+	b.lastUpdateID = ev.FinalUpdateID
+	return nil
+}
+
+// resync 拉取不早于 ev 的快照替换本地数据。调用方持有写锁，请求快照期间暂时释放，读方看到的是未同步状态。
+func (b *OrderBook) resync(ctx context.Context, ev DepthEvent) error {
+	for attempt := 0; ; attempt++ {
+		b.mu.Unlock()
+		depth, err := b.snapshot(ctx, b.Symbol, b.limit)
+		b.mu.Lock()
+		if err != nil {
+			return fmt.Errorf("binance order book %s: snapshot: %w", b.Symbol, err)
+		}
+		if depth.LastUpdateID >= ev.FirstUpdateID-1 {
+			b.bids, b.asks = depth.Bids, depth.Asks
+			b.lastUpdateID = depth.LastUpdateID
+			b.synced = true
+			return nil
+		}
+		if attempt+1 >= snapshotRetries {
+			return fmt.Errorf("binance order book %s: snapshot %d behind update %d", b.Symbol, depth.LastUpdateID, ev.FirstUpdateID)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// upsertLevel 按价格插入、更新或（数量为 0 时）删除一档，保持排序。
+func upsertLevel(levels []PriceLevel, l PriceLevel, desc bool) []PriceLevel {
+	i, found := slices.BinarySearchFunc(levels, l.Price, func(e PriceLevel, price decimal.Decimal) int {
+		if desc {
+			return price.Cmp(e.Price)
+		}
+		return e.Price.Cmp(price)
+	})
+	switch {
+	case l.Qty.IsZero() && found:
+		return slices.Delete(levels, i, i+1)
+	case l.Qty.IsZero():
+		return levels
+	case found:
+		levels[i] = l
+		return levels
+	default:
+		return slices.Insert(levels, i, l)
+	}
+}
+
+// Synced 是否已与服务端同步。
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Depth 返回前 n 档的副本（n <= 0 时返回全部），未同步时 ok 为 false。
+func (b *OrderBook) Depth(n int) (d Depth, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return Depth{}, false
+	}
+	top := func(levels []PriceLevel) []PriceLevel {
+		if n > 0 && len(levels) > n {
+			levels = levels[:n]
+		}
+		return slices.Clone(levels)
+	}
+	return Depth{LastUpdateID: b.lastUpdateID, Bids: top(b.bids), Asks: top(b.asks)}, true
+}
+
+// BestBid 买一，未同步或买盘为空时 ok 为 false。
+func (b *OrderBook) BestBid() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+// BestAsk 卖一，未同步或卖盘为空时 ok 为 false。
+func (b *OrderBook) BestAsk() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.asks[0], true
+}
@@ -0,0 +1,74 @@
+package binance
+
+import (
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 频率限制用量：每个响应都带有 X-MBX-USED-WEIGHT-<interval>（按 IP 计）与
+// X-MBX-ORDER-COUNT-<interval>（按账户计）头，这里记录最近一次的值，供调用方在接近上限时主动降速。
+
+const (
+	usedWeightHeader = "X-Mbx-Used-Weight-"
+	orderCountHeader = "X-Mbx-Order-Count-"
+)
+
+// RateLimitUsage 最近一次响应中的用量，键为小写的时间窗口，如 "1m"、"10s"、"1d"。
+type RateLimitUsage struct {
+	UsedWeight map[string]int
+	OrderCount map[string]int
+	UpdatedAt  time.Time
+}
+
+// Usage 返回 DefaultPool 最近一次记录的频率限制用量。
+func Usage() RateLimitUsage {
+	return DefaultPool.Usage()
+}
+
+// Usage 返回该域名池最近一次记录的频率限制用量（副本）。
+func (p *EndpointPool) Usage() RateLimitUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return RateLimitUsage{
+		UsedWeight: maps.Clone(p.usage.UsedWeight),
+		OrderCount: maps.Clone(p.usage.OrderCount),
+		UpdatedAt:  p.usage.UpdatedAt,
+	}
+}
+
+// recordUsage 解析响应头中的用量；没有相关头时保留上一次的记录。
+func (p *EndpointPool) recordUsage(h http.Header) {
+	weights, orders := map[string]int{}, map[string]int{}
+	for k, v := range h {
+		var target map[string]int
+		var interval string
+		switch {
+		case strings.HasPrefix(k, usedWeightHeader):
+			target, interval = weights, k[len(usedWeightHeader):]
+		case strings.HasPrefix(k, orderCountHeader):
+			target, interval = orders, k[len(orderCountHeader):]
+		default:
+			continue
+		}
+		if n, err := strconv.Atoi(v[0]); err == nil && interval != "" {
+			target[strings.ToLower(interval)] = n
+		}
+	}
+	if len(weights) == 0 && len(orders) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.usage.UpdatedAt = time.Now()
+	if len(weights) > 0 {
+		p.usage.UsedWeight = weights
+	}
+	// 订单计数只出现在下单响应中，行情请求不应清空它
+	if len(orders) > 0 {
+		p.usage.OrderCount = orders
+	}
+}
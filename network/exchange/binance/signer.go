@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+)
+
+// Signer 对请求参数串（即 URL 编码后的 query string）签名，返回 signature 参数的值。
+type Signer interface {
+	Sign(payload string) string
+}
+
+// HMACSigner HMAC-SHA256 签名，对应 Binance 控制台生成的「系统生成」API Key，结果为小写 hex。
+type HMACSigner struct {
+	secret []byte
+}
+
+func NewHMACSigner(secret string) *HMACSigner {
+	return &HMACSigner{secret: []byte(secret)}
+}
+
+func (s *HMACSigner) Sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Ed25519Signer Ed25519 签名，对应「自行生成」的 API Key，结果为 Base64。Binance 推荐使用这种方式：私钥不出本机。
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+func (s *Ed25519Signer) Sign(payload string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, []byte(payload)))
+}
+
+// ParseEd25519PrivateKeyPEM 解析 PKCS#8 PEM 格式的私钥（openssl genpkey -algorithm ed25519 生成的文件）。
+func ParseEd25519PrivateKeyPEM(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("binance: no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("binance: PEM key is not ed25519")
+	}
+	return edKey, nil
+}
@@ -0,0 +1,311 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const apiKeyHeader = "X-MBX-APIKEY"
+
+// codeTimestampOutOfWindow -1021：时间戳超出 recvWindow，通常是本地时钟漂移。
+const codeTimestampOutOfWindow = -1021
+
+// SpotService 需要 API Key 的现货接口：账户、下单与用户数据流。
+// 签名请求的 timestamp 按与服务器的时差校正：首次请求前自动调用 SyncTime，
+// 遇到 -1021 时重新校时并重试一次（该错误表示请求未被受理，重试是安全的）。
+type SpotService struct {
+	APIKey            string
+	Signer            Signer
+	RecvWindow        time.Duration // 默认 5 秒，上限 60 秒
+	Pool              *EndpointPool // 为 nil 时使用 DefaultPool
+	KeepAliveInterval time.Duration // 用户数据流续期 listenKey 的间隔，默认 30 分钟
+
+	mu         sync.Mutex
+	timeSynced bool
+	timeOffset time.Duration // 服务器时间 - 本地时间
+}
+
+func NewSpotService(apiKey string, signer Signer) *SpotService {
+	return &SpotService{APIKey: apiKey, Signer: signer, RecvWindow: 5 * time.Second, Pool: DefaultPool}
+}
+
+// SyncTime 用 GetServerTime 测量本地与服务器的时差，取请求往返的中点估算。
+func (s *SpotService) SyncTime(ctx context.Context) error {
+	start := time.Now()
+	server, err := (&MarketService{Pool: s.Pool}).GetServerTime(ctx)
+	if err != nil {
+		return err
+	}
+	rtt := time.Since(start)
+	s.mu.Lock()
+	s.timeOffset = server.Sub(start.Add(rtt / 2))
+	s.timeSynced = true
+	s.mu.Unlock()
+	return nil
+}
+
+// TimeOffset 最近一次 SyncTime 测得的时差（服务器时间 - 本地时间）。
+func (s *SpotService) TimeOffset() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timeOffset
+}
+
+func (s *SpotService) serverNow(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	synced, offset := s.timeSynced, s.timeOffset
+	s.mu.Unlock()
+	if !synced {
+		if err := s.SyncTime(ctx); err != nil {
+			return time.Time{}, err
+		}
+		offset = s.TimeOffset()
+	}
+	return time.Now().Add(offset), nil
+}
+
+// signed 发送 SIGNED 请求：追加 timestamp、recvWindow 与 signature。
+func (s *SpotService) signed(ctx context.Context, method, path string, params url.Values, out any) error {
+	if s.Signer == nil {
+		return errors.New("binance: signer is required for signed endpoints")
+	}
+	for attempt := 0; ; attempt++ {
+		now, err := s.serverNow(ctx)
+		if err != nil {
+			return err
+		}
+		p := url.Values{}
+		for k, v := range params {
+			p[k] = v
+		}
+		p.Set("timestamp", strconv.FormatInt(now.UnixMilli(), 10))
+		if s.RecvWindow > 0 {
+			p.Set("recvWindow", strconv.FormatInt(s.RecvWindow.Milliseconds(), 10))
+		}
+		// signature 须为最后一个参数，且签名内容与实际发送的查询串逐字节一致
+		query := p.Encode()
+		query += "&signature=" + url.QueryEscape(s.Signer.Sign(query))
+
+		r := request{method: method, path: path, rawQuery: query, header: s.header()}
+		err = poolOrDefault(s.Pool).do(ctx, r, out)
+		var apiErr *APIError
+		if attempt == 0 && errors.As(err, &apiErr) && apiErr.Code == codeTimestampOutOfWindow {
+			if err := s.SyncTime(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		return err
+	}
+}
+
+// keyed 发送只需 API Key、无需签名的请求（USER_STREAM）。
+func (s *SpotService) keyed(ctx context.Context, method, path string, params url.Values, out any) error {
+	return poolOrDefault(s.Pool).do(ctx, request{method: method, path: path, params: params, header: s.header()}, out)
+}
+
+func (s *SpotService) header() http.Header {
+	return http.Header{apiKeyHeader: {s.APIKey}}
+}
+
+// -----------------------------
+// 账户
+// -----------------------------
+
+// Balance 单个资产余额。
+type Balance struct {
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
+}
+
+// Account 账户信息（/api/v3/account）。
+type Account struct {
+	MakerCommission int       `json:"makerCommission"`
+	TakerCommission int       `json:"takerCommission"`
+	CanTrade        bool      `json:"canTrade"`
+	CanWithdraw     bool      `json:"canWithdraw"`
+	CanDeposit      bool      `json:"canDeposit"`
+	UpdateTime      int64     `json:"updateTime"`
+	AccountType     string    `json:"accountType"`
+	Balances        []Balance `json:"balances"`
+	Permissions     []string  `json:"permissions"`
+	UID             int64     `json:"uid"`
+}
+
+// Balance 按资产名查找余额，不存在时返回零余额。
+func (a Account) Balance(asset string) Balance {
+	for _, b := range a.Balances {
+		if b.Asset == asset {
+			return b
+		}
+	}
+	return Balance{Asset: asset}
+}
+
+// GetAccount 查询账户信息与余额，omitZeroBalances 为 true 时不返回零余额资产。
+func (s *SpotService) GetAccount(ctx context.Context, omitZeroBalances bool) (Account, error) {
+	params := url.Values{}
+	if omitZeroBalances {
+		params.Set("omitZeroBalances", "true")
+	}
+	var acc Account
+	err := s.signed(ctx, http.MethodGet, "/api/v3/account", params, &acc)
+	return acc, err
+}
+
+// -----------------------------
+// 订单
+// -----------------------------
+
+type (
+	OrderSide   string
+	OrderType   string
+	TimeInForce string
+	OrderStatus string
+)
+
+const (
+	SideBuy  OrderSide = "BUY"
+	SideSell OrderSide = "SELL"
+
+	OrderTypeLimit           OrderType = "LIMIT"
+	OrderTypeMarket          OrderType = "MARKET"
+	OrderTypeStopLoss        OrderType = "STOP_LOSS"
+	OrderTypeStopLossLimit   OrderType = "STOP_LOSS_LIMIT"
+	OrderTypeTakeProfit      OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
+	OrderTypeLimitMaker      OrderType = "LIMIT_MAKER"
+
+	TimeInForceGTC TimeInForce = "GTC"
+	TimeInForceIOC TimeInForce = "IOC"
+	TimeInForceFOK TimeInForce = "FOK"
+
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+)
+
+// NewOrder 下单参数，零值字段不发送。
+// 市价单用 Quantity（按基础资产）或 QuoteOrderQty（按计价资产金额）二选一。
+type NewOrder struct {
+	Symbol        string
+	Side          OrderSide
+	Type          OrderType
+	TimeInForce   TimeInForce
+	Quantity      decimal.Decimal
+	QuoteOrderQty decimal.Decimal
+	Price         decimal.Decimal
+	StopPrice     decimal.Decimal
+	// ClientOrderID 自定义订单号；下单超时后可用它查询订单是否已创建，避免重复下单
+	ClientOrderID string
+}
+
+func (o NewOrder) params() url.Values {
+	p := url.Values{"symbol": {o.Symbol}, "side": {string(o.Side)}, "type": {string(o.Type)}, "newOrderRespType": {"FULL"}}
+	setIfNotEmpty(p, "timeInForce", string(o.TimeInForce))
+	setIfNotEmpty(p, "newClientOrderId", o.ClientOrderID)
+	for k, v := range map[string]decimal.Decimal{
+		"quantity": o.Quantity, "quoteOrderQty": o.QuoteOrderQty, "price": o.Price, "stopPrice": o.StopPrice,
+	} {
+		if !v.IsZero() {
+			p.Set(k, v.String())
+		}
+	}
+	return p
+}
+
+// Fill 订单的一笔成交。
+type Fill struct {
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeID         int64           `json:"tradeId"`
+}
+
+// Order 订单，下单、撤单、查询共用；Fills 仅下单响应中有。
+type Order struct {
+	Symbol              string          `json:"symbol"`
+	OrderID             int64           `json:"orderId"`
+	OrderListID         int64           `json:"orderListId"`
+	ClientOrderID       string          `json:"clientOrderId"`
+	OrigClientOrderID   string          `json:"origClientOrderId"`
+	Price               decimal.Decimal `json:"price"`
+	OrigQty             decimal.Decimal `json:"origQty"`
+	ExecutedQty         decimal.Decimal `json:"executedQty"`
+	CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+	Status              OrderStatus     `json:"status"`
+	TimeInForce         TimeInForce     `json:"timeInForce"`
+	Type                OrderType       `json:"type"`
+	Side                OrderSide       `json:"side"`
+	StopPrice           decimal.Decimal `json:"stopPrice"`
+	Time                int64           `json:"time"`
+	UpdateTime          int64           `json:"updateTime"`
+	TransactTime        int64           `json:"transactTime"`
+	WorkingTime         int64           `json:"workingTime"`
+	IsWorking           bool            `json:"isWorking"`
+	Fills               []Fill          `json:"fills"`
+}
+
+// CreateOrder 下单（/api/v3/order，响应类型 FULL）。
+func (s *SpotService) CreateOrder(ctx context.Context, o NewOrder) (Order, error) {
+	var order Order
+	err := s.signed(ctx, http.MethodPost, "/api/v3/order", o.params(), &order)
+	return order, err
+}
+
+// TestOrder 校验下单参数与签名但不真正下单（/api/v3/order/test）。
+func (s *SpotService) TestOrder(ctx context.Context, o NewOrder) error {
+	var resp struct{}
+	return s.signed(ctx, http.MethodPost, "/api/v3/order/test", o.params(), &resp)
+}
+
+// OrderRef 定位一个订单：OrderID 与 ClientOrderID 二选一。
+type OrderRef struct {
+	Symbol        string
+	OrderID       int64
+	ClientOrderID string
+}
+
+func (r OrderRef) params() url.Values {
+	p := url.Values{"symbol": {r.Symbol}}
+	if r.OrderID > 0 {
+		p.Set("orderId", strconv.FormatInt(r.OrderID, 10))
+	}
+	setIfNotEmpty(p, "origClientOrderId", r.ClientOrderID)
+	return p
+}
+
+// GetOrder 查询订单状态。
+func (s *SpotService) GetOrder(ctx context.Context, ref OrderRef) (Order, error) {
+	var order Order
+	err := s.signed(ctx, http.MethodGet, "/api/v3/order", ref.params(), &order)
+	return order, err
+}
+
+// CancelOrder 撤销订单，返回撤单后的订单状态。
+func (s *SpotService) CancelOrder(ctx context.Context, ref OrderRef) (Order, error) {
+	var order Order
+	err := s.signed(ctx, http.MethodDelete, "/api/v3/order", ref.params(), &order)
+	return order, err
+}
+
+// GetOpenOrders 查询当前挂单，symbol 为空时查询全部交易对（权重 80）。
+func (s *SpotService) GetOpenOrders(ctx context.Context, symbol string) ([]Order, error) {
+	params := url.Values{}
+	setIfNotEmpty(params, "symbol", symbol)
+	var orders []Order
+	err := s.signed(ctx, http.MethodGet, "/api/v3/openOrders", params, &orders)
+	return orders, err
+}
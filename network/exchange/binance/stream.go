@@ -0,0 +1,312 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"golang.org/x/net/websocket"
+)
+
+// StreamBaseURL 行情 WebSocket 地址；只需行情时也可使用 wss://data-stream.binance.vision。
+const StreamBaseURL = "wss://stream.binance.com:9443"
+
+// maxStreamsPerConn 单个连接最多订阅的 stream 数量。
+const maxStreamsPerConn = 1024
+
+// StreamClient 行情 WebSocket 客户端，统一走 combined stream（/stream?streams=a/b/c），
+// 断线后按指数退避自动重连。服务端每 20 秒发送 ping，由底层连接自动回复 pong；
+// 连接每 24 小时会被服务端断开一次，同样由重连处理。
+type StreamClient struct {
+	BaseURL     string        // 默认 StreamBaseURL，测试时可指向 httptest 服务
+	ReadTimeout time.Duration // 超过该时长未收到任何消息视为连接失效，默认 3 分钟
+	MinBackoff  time.Duration // 重连等待的初始值，默认 1 秒
+	MaxBackoff  time.Duration // 重连等待的上限，默认 1 分钟
+	OnError     func(error)   // 连接与解码错误的回调，为 nil 时记录日志
+}
+
+func NewStreamClient() *StreamClient {
+	return &StreamClient{
+		BaseURL:     StreamBaseURL,
+		ReadTimeout: 3 * time.Minute,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+	}
+}
+
+// StreamMessage combined stream 推送的一条消息。
+type StreamMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Run 订阅 streams 并把每条消息交给 handle，阻塞直到 ctx 结束，返回 ctx.Err()。
+// handle 在读循环中同步调用，耗时操作应自行转交其它 goroutine。
+func (c *StreamClient) Run(ctx context.Context, streams []string, handle func(StreamMessage)) error {
+	if len(streams) == 0 || len(streams) > maxStreamsPerConn {
+		return fmt.Errorf("binance stream: need 1 to %d streams, got %d", maxStreamsPerConn, len(streams))
+	}
+	base := c.BaseURL
+	if base == "" {
+		base = StreamBaseURL
+	}
+	base = strings.TrimRight(base, "/")
+	url := base + "/stream?streams=" + strings.Join(streams, "/")
+	// 握手需要 Origin，Binance 不校验其取值
+	origin := "http" + strings.TrimPrefix(base, "ws")
+
+	backoff := c.MinBackoff
+	for {
+		received, err := c.runOnce(ctx, url, origin, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.reportError(fmt.Errorf("binance stream: %w", err))
+		if received {
+			backoff = c.MinBackoff
+		}
+		backoff = max(backoff, time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, max(c.MaxBackoff, c.MinBackoff))
+	}
+}
+
+// runOnce 建立一次连接并读取到断开为止，received 表示期间是否收到过消息。
+func (c *StreamClient) runOnce(ctx context.Context, url, origin string, handle func(StreamMessage)) (received bool, err error) {
+	cfg, err := websocket.NewConfig(url, origin)
+	if err != nil {
+		return false, err
+	}
+	ws, err := cfg.DialContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer ws.Close()
+	stop := context.AfterFunc(ctx, func() { ws.Close() })
+	defer stop()
+
+	timeout := c.ReadTimeout
+	if timeout <= 0 {
+		timeout = 3 * time.Minute
+	}
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return received, err
+		}
+		var data []byte
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			return received, err
+		}
+		received = true
+		var msg StreamMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Stream == "" {
+			c.reportError(fmt.Errorf("binance stream: unexpected message %.200s", data))
+			continue
+		}
+		handle(msg)
+	}
+}
+
+func (c *StreamClient) reportError(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+		return
+	}
+	log.Warn().Err(err).Msg("binance stream")
+}
+
+// -----------------------------
+// Stream 名称
+// -----------------------------
+
+func TradeStream(symbol string) string    { return strings.ToLower(symbol) + "@trade" }
+func AggTradeStream(symbol string) string { return strings.ToLower(symbol) + "@aggTrade" }
+
+// KlineStream interval 如 "1m"、"1h"、"1d"。
+func KlineStream(symbol, interval string) string {
+	return strings.ToLower(symbol) + "@kline_" + interval
+}
+
+// DepthStream 增量深度，每 1000ms 推送一次。
+func DepthStream(symbol string) string { return strings.ToLower(symbol) + "@depth" }
+
+// FastDepthStream 增量深度，每 100ms 推送一次。
+func FastDepthStream(symbol string) string { return strings.ToLower(symbol) + "@depth@100ms" }
+
+func BookTickerStream(symbol string) string { return strings.ToLower(symbol) + "@bookTicker" }
+func MiniTickerStream(symbol string) string { return strings.ToLower(symbol) + "@miniTicker" }
+
+// AllMiniTickersStream 全市场精简 ticker，每秒推送有变动的交易对（数组）。
+const AllMiniTickersStream = "!miniTicker@arr"
+
+// -----------------------------
+// 推送事件
+// -----------------------------
+//
+// JSON 解码对字段名大小写不敏感时会回退匹配，Binance 又大量使用 "m"/"M"、"t"/"T" 这类仅大小写不同的键，
+// 因此事件结构体为这类键成对声明字段，避免被大小写不同的键覆盖。
+
+// TradeEvent <symbol>@trade
+type TradeEvent struct {
+	EventType    string          `json:"e"`
+	EventTime    int64           `json:"E"`
+	Symbol       string          `json:"s"`
+	TradeID      int64           `json:"t"`
+	Price        decimal.Decimal `json:"p"`
+	Qty          decimal.Decimal `json:"q"`
+	TradeTime    int64           `json:"T"`
+	IsBuyerMaker bool            `json:"m"`
+	IsBestMatch  bool            `json:"M"`
+}
+
+// AggTradeEvent <symbol>@aggTrade
+type AggTradeEvent struct {
+	EventType    string          `json:"e"`
+	EventTime    int64           `json:"E"`
+	Symbol       string          `json:"s"`
+	AggTradeID   int64           `json:"a"`
+	Price        decimal.Decimal `json:"p"`
+	Qty          decimal.Decimal `json:"q"`
+	FirstTradeID int64           `json:"f"`
+	LastTradeID  int64           `json:"l"`
+	TradeTime    int64           `json:"T"`
+	IsBuyerMaker bool            `json:"m"`
+	IsBestMatch  bool            `json:"M"`
+}
+
+// KlineEvent <symbol>@kline_<interval>
+type KlineEvent struct {
+	EventType string      `json:"e"`
+	EventTime int64       `json:"E"`
+	Symbol    string      `json:"s"`
+	Kline     StreamKline `json:"k"`
+}
+
+// StreamKline 推送中的 K 线，IsFinal 为 true 表示该 K 线已收盘。
+type StreamKline struct {
+	StartTime           int64           `json:"t"`
+	CloseTime           int64           `json:"T"`
+	Symbol              string          `json:"s"`
+	Interval            string          `json:"i"`
+	FirstTradeID        int64           `json:"f"`
+	LastTradeID         int64           `json:"L"`
+	Open                decimal.Decimal `json:"o"`
+	Close               decimal.Decimal `json:"c"`
+	High                decimal.Decimal `json:"h"`
+	Low                 decimal.Decimal `json:"l"`
+	Volume              decimal.Decimal `json:"v"`
+	Trades              int64           `json:"n"`
+	IsFinal             bool            `json:"x"`
+	QuoteVolume         decimal.Decimal `json:"q"`
+	TakerBuyVolume      decimal.Decimal `json:"V"`
+	TakerBuyQuoteVolume decimal.Decimal `json:"Q"`
+}
+
+// DepthEvent <symbol>@depth 增量深度，数量为 0 表示删除该价位。
+type DepthEvent struct {
+	EventType     string       `json:"e"`
+	EventTime     int64        `json:"E"`
+	Symbol        string       `json:"s"`
+	FirstUpdateID int64        `json:"U"`
+	FinalUpdateID int64        `json:"u"`
+	Bids          []PriceLevel `json:"b"`
+	Asks          []PriceLevel `json:"a"`
+}
+
+// BookTickerEvent <symbol>@bookTicker 买卖一档实时推送（该 stream 没有 e / E 字段）。
+type BookTickerEvent struct {
+	UpdateID int64           `json:"u"`
+	Symbol   string          `json:"s"`
+	BidPrice decimal.Decimal `json:"b"`
+	BidQty   decimal.Decimal `json:"B"`
+	AskPrice decimal.Decimal `json:"a"`
+	AskQty   decimal.Decimal `json:"A"`
+}
+
+// MiniTickerEvent <symbol>@miniTicker / !miniTicker@arr
+type MiniTickerEvent struct {
+	EventType   string          `json:"e"`
+	EventTime   int64           `json:"E"`
+	Symbol      string          `json:"s"`
+	Close       decimal.Decimal `json:"c"`
+	Open        decimal.Decimal `json:"o"`
+	High        decimal.Decimal `json:"h"`
+	Low         decimal.Decimal `json:"l"`
+	Volume      decimal.Decimal `json:"v"`
+	QuoteVolume decimal.Decimal `json:"q"`
+}
+
+// StreamHandlers 按事件类型分发 StreamMessage，未设置的回调对应的消息被忽略：
+//
+//	c.Run(ctx, streams, binance.StreamHandlers{Trade: onTrade}.Handle)
+type StreamHandlers struct {
+	Trade      func(TradeEvent)
+	AggTrade   func(AggTradeEvent)
+	Kline      func(KlineEvent)
+	Depth      func(DepthEvent)
+	BookTicker func(BookTickerEvent)
+	MiniTicker func(MiniTickerEvent)
+	Error      func(stream string, err error) // 解码失败时调用，为 nil 时记录日志
+}
+
+var errUnknownStream = errors.New("unknown stream type")
+
+// Handle 根据 stream 名称解码并调用对应回调。
+func (h StreamHandlers) Handle(msg StreamMessage) {
+	var err error
+	_, kind, _ := strings.Cut(msg.Stream, "@")
+	switch {
+	case kind == "trade":
+		err = dispatch(msg.Data, h.Trade)
+	case kind == "aggTrade":
+		err = dispatch(msg.Data, h.AggTrade)
+	case strings.HasPrefix(kind, "kline_"):
+		err = dispatch(msg.Data, h.Kline)
+	case kind == "depth" || strings.HasPrefix(kind, "depth@"):
+		err = dispatch(msg.Data, h.Depth)
+	case kind == "bookTicker":
+		err = dispatch(msg.Data, h.BookTicker)
+	case kind == "miniTicker":
+		err = dispatch(msg.Data, h.MiniTicker)
+	case msg.Stream == AllMiniTickersStream:
+		if h.MiniTicker != nil {
+			var events []MiniTickerEvent
+			if err = json.Unmarshal(msg.Data, &events); err == nil {
+				for _, ev := range events {
+					h.MiniTicker(ev)
+				}
+			}
+		}
+	default:
+		err = errUnknownStream
+	}
+	if err == nil {
+		return
+	}
+	if h.Error != nil {
+		h.Error(msg.Stream, err)
+		return
+	}
+	log.Warn().Str("stream", msg.Stream).Err(err).Msg("binance stream: decode")
+}
+
+func dispatch[T any](data []byte, fn func(T)) error {
+	if fn == nil {
+		return nil
+	}
+	var ev T
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return err
+	}
+	fn(ev)
+	return nil
+}
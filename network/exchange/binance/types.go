@@ -0,0 +1,253 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+// 时间字段与 Binance 接口保持一致，均为 Unix 毫秒时间戳；价格与数量一律使用 decimal.Decimal。
+
+// APIError Binance 返回的业务错误，如 {"code":-1121,"msg":"Invalid symbol."}。
+type APIError struct {
+	StatusCode int           `json:"-"`
+	Code       int           `json:"code"`
+	Msg        string        `json:"msg"`
+	RetryAfter time.Duration `json:"-"` // 418 / 429 时需等待的时长
+}
+
+func (e *APIError) Error() string {
+	if e.RateLimited() {
+		return fmt.Sprintf("binance: http %d, code %d: %s (retry after %s)", e.StatusCode, e.Code, e.Msg, e.RetryAfter)
+	}
+	return fmt.Sprintf("binance: http %d, code %d: %s", e.StatusCode, e.Code, e.Msg)
+}
+
+// RateLimited 是否为频率限制（429）或 IP 封禁（418）。
+func (e *APIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusTeapot
+}
+
+// PriceLevel 订单簿的一档，接口返回 ["价格","数量"]。
+type PriceLevel struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+}
+
+func (p *PriceLevel) UnmarshalJSON(b []byte) error {
+	var raw [2]decimal.Decimal
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("binance: price level: %w", err)
+	}
+	p.Price, p.Qty = raw[0], raw[1]
+	return nil
+}
+
+// Depth 订单簿快照，买单价格从高到低，卖单价格从低到高。
+type Depth struct {
+	LastUpdateID int64        `json:"lastUpdateId"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+}
+
+// Trade 成交记录（/api/v3/trades、/api/v3/historicalTrades）。
+type Trade struct {
+	ID           int64           `json:"id"`
+	Price        decimal.Decimal `json:"price"`
+	Qty          decimal.Decimal `json:"qty"`
+	QuoteQty     decimal.Decimal `json:"quoteQty"`
+	Time         int64           `json:"time"`
+	IsBuyerMaker bool            `json:"isBuyerMaker"`
+	IsBestMatch  bool            `json:"isBestMatch"`
+}
+
+// AggTrade 归集成交（/api/v3/aggTrades），同一价格、同一吃单方向的连续成交合并为一条。
+type AggTrade struct {
+	ID           int64           `json:"a"`
+	Price        decimal.Decimal `json:"p"`
+	Qty          decimal.Decimal `json:"q"`
+	FirstTradeID int64           `json:"f"`
+	LastTradeID  int64           `json:"l"`
+	Time         int64           `json:"T"`
+	IsBuyerMaker bool            `json:"m"`
+	IsBestMatch  bool            `json:"M"`
+}
+
+// Kline K 线，接口以数组返回：
+// [开盘时间, 开, 高, 低, 收, 成交量, 收盘时间, 成交额, 成交笔数, 主动买入成交量, 主动买入成交额, 忽略]。
+type Kline struct {
+	OpenTime            int64
+	Open                decimal.Decimal
+	High                decimal.Decimal
+	Low                 decimal.Decimal
+	Close               decimal.Decimal
+	Volume              decimal.Decimal
+	CloseTime           int64
+	QuoteVolume         decimal.Decimal
+	Trades              int64
+	TakerBuyVolume      decimal.Decimal
+	TakerBuyQuoteVolume decimal.Decimal
+}
+
+func (k *Kline) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("binance: kline: %w", err)
+	}
+	if len(raw) < 11 {
+		return fmt.Errorf("binance: kline: expected at least 11 fields, got %d", len(raw))
+	}
+	targets := []any{
+		&k.OpenTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume,
+		&k.CloseTime, &k.QuoteVolume, &k.Trades, &k.TakerBuyVolume, &k.TakerBuyQuoteVolume,
+	}
+	for i, t := range targets {
+		if err := json.Unmarshal(raw[i], t); err != nil {
+			return fmt.Errorf("binance: kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AvgPrice 当前平均价格（/api/v3/avgPrice）。
+type AvgPrice struct {
+	Mins      int             `json:"mins"`
+	Price     decimal.Decimal `json:"price"`
+	CloseTime int64           `json:"closeTime"`
+}
+
+// 行情统计接口的 type 参数。
+const (
+	TickerFull = "FULL"
+	TickerMini = "MINI" // 不含价格变动、买卖一档等字段，对应字段为零值
+)
+
+// TickerStats 价格变动统计，24hr / tradingDay / 滚动窗口接口共用。
+// BidPrice、BidQty、AskPrice、AskQty、PrevClosePrice、LastQty 仅 24hr 接口返回。
+type TickerStats struct {
+	Symbol             string          `json:"symbol"`
+	PriceChange        decimal.Decimal `json:"priceChange"`
+	PriceChangePercent decimal.Decimal `json:"priceChangePercent"`
+	WeightedAvgPrice   decimal.Decimal `json:"weightedAvgPrice"`
+	PrevClosePrice     decimal.Decimal `json:"prevClosePrice"`
+	LastPrice          decimal.Decimal `json:"lastPrice"`
+	LastQty            decimal.Decimal `json:"lastQty"`
+	BidPrice           decimal.Decimal `json:"bidPrice"`
+	BidQty             decimal.Decimal `json:"bidQty"`
+	AskPrice           decimal.Decimal `json:"askPrice"`
+	AskQty             decimal.Decimal `json:"askQty"`
+	OpenPrice          decimal.Decimal `json:"openPrice"`
+	HighPrice          decimal.Decimal `json:"highPrice"`
+	LowPrice           decimal.Decimal `json:"lowPrice"`
+	Volume             decimal.Decimal `json:"volume"`
+	QuoteVolume        decimal.Decimal `json:"quoteVolume"`
+	OpenTime           int64           `json:"openTime"`
+	CloseTime          int64           `json:"closeTime"`
+	FirstID            int64           `json:"firstId"`
+	LastID             int64           `json:"lastId"`
+	Count              int64           `json:"count"`
+}
+
+// TickerPrice 最新价格（/api/v3/ticker/price）。
+type TickerPrice struct {
+	Symbol string          `json:"symbol"`
+	Price  decimal.Decimal `json:"price"`
+}
+
+// BookTicker 买卖一档（/api/v3/ticker/bookTicker）。
+type BookTicker struct {
+	Symbol   string          `json:"symbol"`
+	BidPrice decimal.Decimal `json:"bidPrice"`
+	BidQty   decimal.Decimal `json:"bidQty"`
+	AskPrice decimal.Decimal `json:"askPrice"`
+	AskQty   decimal.Decimal `json:"askQty"`
+}
+
+// ExchangeInfo 交易规则与交易对信息（/api/v3/exchangeInfo）。
+type ExchangeInfo struct {
+	Timezone   string       `json:"timezone"`
+	ServerTime int64        `json:"serverTime"`
+	RateLimits []RateLimit  `json:"rateLimits"`
+	Symbols    []SymbolInfo `json:"symbols"`
+}
+
+// Symbol 按名称查找交易对。
+func (e ExchangeInfo) Symbol(symbol string) (SymbolInfo, bool) {
+	for _, s := range e.Symbols {
+		if s.Symbol == symbol {
+			return s, true
+		}
+	}
+	return SymbolInfo{}, false
+}
+
+// RateLimit 频率限制规则，RateLimitType 为 REQUEST_WEIGHT / ORDERS / RAW_REQUESTS。
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+}
+
+// SymbolInfo 交易对信息。
+type SymbolInfo struct {
+	Symbol                     string         `json:"symbol"`
+	Status                     string         `json:"status"`
+	BaseAsset                  string         `json:"baseAsset"`
+	BaseAssetPrecision         int            `json:"baseAssetPrecision"`
+	QuoteAsset                 string         `json:"quoteAsset"`
+	QuoteAssetPrecision        int            `json:"quoteAssetPrecision"`
+	OrderTypes                 []string       `json:"orderTypes"`
+	IcebergAllowed             bool           `json:"icebergAllowed"`
+	OcoAllowed                 bool           `json:"ocoAllowed"`
+	IsSpotTradingAllowed       bool           `json:"isSpotTradingAllowed"`
+	IsMarginTradingAllowed     bool           `json:"isMarginTradingAllowed"`
+	QuoteOrderQtyMarketAllowed bool           `json:"quoteOrderQtyMarketAllowed"`
+	Permissions                []string       `json:"permissions"`
+	Filters                    []SymbolFilter `json:"filters"`
+}
+
+// Filter 按类型取过滤器，如 "PRICE_FILTER"、"LOT_SIZE"、"NOTIONAL"。
+func (s SymbolInfo) Filter(filterType string) (SymbolFilter, bool) {
+	for _, f := range s.Filters {
+		if f.FilterType == filterType {
+			return f, true
+		}
+	}
+	return SymbolFilter{}, false
+}
+
+// SymbolFilter 交易对过滤器，各类型只填充各自的字段，其余为零值。
+type SymbolFilter struct {
+	FilterType string `json:"filterType"`
+
+	// PRICE_FILTER
+	MinPrice decimal.Decimal `json:"minPrice"`
+	MaxPrice decimal.Decimal `json:"maxPrice"`
+	TickSize decimal.Decimal `json:"tickSize"`
+
+	// LOT_SIZE / MARKET_LOT_SIZE
+	MinQty   decimal.Decimal `json:"minQty"`
+	MaxQty   decimal.Decimal `json:"maxQty"`
+	StepSize decimal.Decimal `json:"stepSize"`
+
+	// MIN_NOTIONAL / NOTIONAL
+	MinNotional      decimal.Decimal `json:"minNotional"`
+	MaxNotional      decimal.Decimal `json:"maxNotional"`
+	ApplyMinToMarket bool            `json:"applyMinToMarket"`
+	AvgPriceMins     int             `json:"avgPriceMins"`
+
+	// PERCENT_PRICE_BY_SIDE
+	BidMultiplierUp   decimal.Decimal `json:"bidMultiplierUp"`
+	BidMultiplierDown decimal.Decimal `json:"bidMultiplierDown"`
+	AskMultiplierUp   decimal.Decimal `json:"askMultiplierUp"`
+	AskMultiplierDown decimal.Decimal `json:"askMultiplierDown"`
+
+	// ICEBERG_PARTS / MAX_NUM_ORDERS / MAX_NUM_ALGO_ORDERS
+	Limit            int `json:"limit"`
+	MaxNumOrders     int `json:"maxNumOrders"`
+	MaxNumAlgoOrders int `json:"maxNumAlgoOrders"`
+}
@@ -0,0 +1,189 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+)
+
+// -----------------------------
+// 用户数据流（listenKey）
+// -----------------------------
+//
+// listenKey 有效期 60 分钟，需每 30 分钟续期一次；续期失败或收到 listenKeyExpired 事件时重新创建。
+
+// listenKeyKeepAlive 默认续期间隔。
+const listenKeyKeepAlive = 30 * time.Minute
+
+// CreateListenKey 创建用户数据流的 listenKey（已存在有效的 key 时返回同一个并续期）。
+func (s *SpotService) CreateListenKey(ctx context.Context) (string, error) {
+	var resp struct {
+		ListenKey string `json:"listenKey"`
+	}
+	err := s.keyed(ctx, http.MethodPost, "/api/v3/userDataStream", nil, &resp)
+	return resp.ListenKey, err
+}
+
+// KeepAliveListenKey 续期 listenKey。
+func (s *SpotService) KeepAliveListenKey(ctx context.Context, listenKey string) error {
+	var resp struct{}
+	return s.keyed(ctx, http.MethodPut, "/api/v3/userDataStream", url.Values{"listenKey": {listenKey}}, &resp)
+}
+
+// CloseListenKey 关闭用户数据流。
+func (s *SpotService) CloseListenKey(ctx context.Context, listenKey string) error {
+	var resp struct{}
+	return s.keyed(ctx, http.MethodDelete, "/api/v3/userDataStream", url.Values{"listenKey": {listenKey}}, &resp)
+}
+
+// AccountUpdateEvent outboundAccountPosition：余额发生变化的资产。
+type AccountUpdateEvent struct {
+	EventType      string          `json:"e"`
+	EventTime      int64           `json:"E"`
+	LastUpdateTime int64           `json:"u"`
+	Balances       []StreamBalance `json:"B"`
+}
+
+// StreamBalance 用户数据流中的资产余额。
+type StreamBalance struct {
+	Asset  string          `json:"a"`
+	Free   decimal.Decimal `json:"f"`
+	Locked decimal.Decimal `json:"l"`
+}
+
+// BalanceUpdateEvent balanceUpdate：充值、提现或划转引起的余额变动。
+type BalanceUpdateEvent struct {
+	EventType string          `json:"e"`
+	EventTime int64           `json:"E"`
+	Asset     string          `json:"a"`
+	Delta     decimal.Decimal `json:"d"`
+	ClearTime int64           `json:"T"`
+}
+
+// ExecutionReport executionReport：订单状态变化与成交。
+// 该事件几乎所有键都有大小写两种形式，而 JSON 解码在找不到同名字段时会按大小写不敏感匹配，
+// 因此 I / M 等保留字段也要声明，避免覆盖 i / m；v / A / B 仅在触发自成交保护时出现。
+type ExecutionReport struct {
+	EventType               string          `json:"e"`
+	EventTime               int64           `json:"E"`
+	Symbol                  string          `json:"s"`
+	ClientOrderID           string          `json:"c"`
+	Side                    OrderSide       `json:"S"`
+	OrderType               OrderType       `json:"o"`
+	TimeInForce             TimeInForce     `json:"f"`
+	Qty                     decimal.Decimal `json:"q"`
+	Price                   decimal.Decimal `json:"p"`
+	StopPrice               decimal.Decimal `json:"P"`
+	IcebergQty              decimal.Decimal `json:"F"`
+	OrderListID             int64           `json:"g"`
+	OrigClientOrderID       string          `json:"C"`
+	ExecutionType           string          `json:"x"` // NEW / CANCELED / REPLACED / REJECTED / TRADE / EXPIRED
+	OrderStatus             OrderStatus     `json:"X"`
+	RejectReason            string          `json:"r"`
+	OrderID                 int64           `json:"i"`
+	LastExecutedQty         decimal.Decimal `json:"l"`
+	CumulativeQty           decimal.Decimal `json:"z"`
+	LastExecutedPrice       decimal.Decimal `json:"L"`
+	Commission              decimal.Decimal `json:"n"`
+	CommissionAsset         string          `json:"N"`
+	TransactionTime         int64           `json:"T"`
+	TradeID                 int64           `json:"t"`
+	ReservedI               int64           `json:"I"`
+	IsWorking               bool            `json:"w"`
+	IsMaker                 bool            `json:"m"`
+	ReservedM               bool            `json:"M"`
+	CreateTime              int64           `json:"O"`
+	CumulativeQuoteQty      decimal.Decimal `json:"Z"`
+	LastQuoteQty            decimal.Decimal `json:"Y"`
+	QuoteOrderQty           decimal.Decimal `json:"Q"`
+	WorkingTime             int64           `json:"W"`
+	SelfTradePreventionMode string          `json:"V"`
+	PreventedMatchID        int64           `json:"v"`
+	PreventedQty            decimal.Decimal `json:"A"`
+	LastPreventedQty        decimal.Decimal `json:"B"`
+}
+
+// UserDataHandlers 用户数据流回调，未设置的事件被忽略。
+type UserDataHandlers struct {
+	Account func(AccountUpdateEvent)
+	Balance func(BalanceUpdateEvent)
+	Order   func(ExecutionReport)
+}
+
+// RunUserDataStream 创建 listenKey 并订阅用户数据流，定时续期；listenKey 失效时自动重建。
+// 阻塞直到 ctx 结束，返回 ctx.Err()。错误通过 c.OnError 报告。
+func (s *SpotService) RunUserDataStream(ctx context.Context, c *StreamClient, h UserDataHandlers) error {
+	backoff := c.MinBackoff
+	for ctx.Err() == nil {
+		listenKey, err := s.CreateListenKey(ctx)
+		if err != nil {
+			c.reportError(fmt.Errorf("binance user data: create listenKey: %w", err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(max(backoff, time.Millisecond)):
+			}
+			backoff = min(max(backoff*2, time.Millisecond), max(c.MaxBackoff, c.MinBackoff))
+			continue
+		}
+		backoff = c.MinBackoff
+		s.runListenKey(ctx, c, listenKey, h)
+	}
+	return ctx.Err()
+}
+
+// runListenKey 使用一个 listenKey 运行，直到 ctx 结束、续期失败或收到 listenKeyExpired。
+func (s *SpotService) runListenKey(ctx context.Context, c *StreamClient, listenKey string, h UserDataHandlers) {
+	keyCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		interval := s.KeepAliveInterval
+		if interval <= 0 {
+			interval = listenKeyKeepAlive
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-keyCtx.Done():
+				return
+			case <-ticker.C:
+				if err := s.KeepAliveListenKey(keyCtx, listenKey); err != nil {
+					c.reportError(fmt.Errorf("binance user data: keepalive: %w", err))
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	_ = c.Run(keyCtx, []string{listenKey}, func(msg StreamMessage) {
+		var head struct {
+			EventType string `json:"e"`
+			EventTime int64  `json:"E"` // 不声明会被当作 "e" 解码
+		}
+		if err := json.Unmarshal(msg.Data, &head); err != nil {
+			c.reportError(fmt.Errorf("binance user data: %w", err))
+			return
+		}
+		var err error
+		switch head.EventType {
+		case "outboundAccountPosition":
+			err = dispatch(msg.Data, h.Account)
+		case "balanceUpdate":
+			err = dispatch(msg.Data, h.Balance)
+		case "executionReport":
+			err = dispatch(msg.Data, h.Order)
+		case "listenKeyExpired":
+			cancel()
+		}
+		if err != nil {
+			c.reportError(fmt.Errorf("binance user data %s: %w", head.EventType, err))
+		}
+	})
+}
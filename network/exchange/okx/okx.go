@@ -1,18 +1,25 @@
 package okx
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/shopspring/decimal"
 )
 
+const defaultBaseURL = "https://www.okx.com"
+
 type OKX struct {
-	Client *http.Client
+	Client  *http.Client
+	BaseURL string // 为空时使用 https://www.okx.com，测试时可指向 httptest 服务
 }
 type PayMethod string
 
@@ -23,91 +30,194 @@ const (
 	PayMethodAll    PayMethod = "all"
 )
 
+// Side 广告方向，以商家视角区分：
+// SideSell 为商家出售数字货币的广告，即用户买入时看到的报价，价格从低到高为优；
+// SideBuy 为商家收购数字货币的广告，即用户卖出时看到的报价，价格从高到低为优。
+type Side string
+
+const (
+	SideSell Side = "sell"
+	SideBuy  Side = "buy"
+)
+
+// ErrNoAds 没有符合条件的广告。
+var ErrNoAds = errors.New("okx: no matching C2C ads")
+
+// APIError 接口返回的非 0 业务码或非 200 状态码。
+type APIError struct {
+	StatusCode int
+	Code       int
+	Msg        string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("okx: http %d, code %d: %s", e.StatusCode, e.Code, e.Msg)
+}
+
 func NewOkxExchangeService() *OKX {
-	return &OKX{Client: &http.Client{}}
+	return &OKX{Client: &http.Client{Timeout: 10 * time.Second}, BaseURL: defaultBaseURL}
 }
 
-// GetTop10Exchanges 获取前10交易商家的 C2C 汇率 可指定不同的货币和支付方式
-func (o *OKX) GetTop10Exchanges(baseCurrency, quoteCurrency string, okxPayMethod PayMethod) ([]*Exchange, error) {
-	url := fmt.Sprintf(
-		"https://www.okx.com/v3/c2c/tradingOrders/books?quoteCurrency=%s&baseCurrency=%s&side=sell&paymentMethod=%s&userType=all&receivingAds=false&limit=10&t=%d",
-		quoteCurrency, baseCurrency, string(okxPayMethod), time.Now().UnixMilli(),
-	)
-	result, err := o.doRequest(url)
-	if err != nil {
-		return nil, err
+// BookQuery C2C 广告查询条件。
+type BookQuery struct {
+	Base      string    // 数字货币，如 "USDT"
+	Quote     string    // 法币，如 "CNY"
+	Side      Side      // 默认 SideSell
+	PayMethod PayMethod // 默认 PayMethodAll
+
+	// Amount 计划成交的法币金额，大于零时只保留单笔限额覆盖该金额、且可用数量足够的广告
+	Amount decimal.Decimal
+
+	// 分页：接口只支持从头取前 N 条，因此翻页会重新取回前 Offset+Limit 条后再截取。
+	// Amount 过滤发生在截取之前，被过滤掉的广告不会由后面的记录补足，
+	// 所以设置了 Amount 时一页可能不足 Limit 条，不足也不代表没有下一页。
+	Limit  int // 每页条数，默认 10
+	Offset int
+}
+
+// GetBook 查询 C2C 广告，按价格从优到劣排序；分页与 Amount 过滤的关系见 BookQuery。
+func (o *OKX) GetBook(ctx context.Context, q BookQuery) ([]*Exchange, error) {
+	if q.Side == "" {
+		q.Side = SideSell
+	}
+	if q.PayMethod == "" {
+		q.PayMethod = PayMethodAll
+	}
+	if q.Limit <= 0 {
+		q.Limit = 10
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
 	}
 
+	params := url.Values{
+		"quoteCurrency": {q.Quote},
+		"baseCurrency":  {q.Base},
+		"side":          {string(q.Side)},
+		"paymentMethod": {string(q.PayMethod)},
+		"userType":      {"all"},
+		"receivingAds":  {"false"},
+		"limit":         {strconv.Itoa(q.Offset + q.Limit)},
+		"t":             {strconv.FormatInt(time.Now().UnixMilli(), 10)},
+	}
 	var data DataResponse
-	err = json.Unmarshal([]byte(result), &data)
-	if err != nil {
+	if err := o.doRequest(ctx, "/v3/c2c/tradingOrders/books?"+params.Encode(), &data); err != nil {
 		return nil, err
 	}
 
-	var exchanges []*Exchange
-	for _, v := range data.Data.Sell {
-		exchanges = append(
-			exchanges, &Exchange{
-				Currency: v.BaseCurrency,
-				ShopName: v.NickName,
-				Price:    v.Price,
-			},
-		)
+	items := data.Data.Sell
+	if q.Side == SideBuy {
+		items = data.Data.Buy
+	}
+	exchanges := make([]*Exchange, 0, len(items))
+	for _, v := range items {
+		e := v.toExchange()
+		if !e.PriceValue.IsPositive() || (q.Amount.IsPositive() && !e.Accepts(q.Amount)) {
+			continue
+		}
+		exchanges = append(exchanges, e)
 	}
+	slices.SortStableFunc(exchanges, func(a, b *Exchange) int {
+		if q.Side == SideBuy {
+			return b.PriceValue.Cmp(a.PriceValue)
+		}
+		return a.PriceValue.Cmp(b.PriceValue)
+	})
 
+	if q.Offset >= len(exchanges) {
+		return []*Exchange{}, nil
+	}
+	exchanges = exchanges[q.Offset:]
+	if len(exchanges) > q.Limit {
+		exchanges = exchanges[:q.Limit]
+	}
 	return exchanges, nil
 }
 
+// BestPrice 返回满足条件的最优广告，如“买入 5000 CNY 的 USDT 最便宜的商家”：
+//
+//	o.BestPrice(ctx, BookQuery{Base: "USDT", Quote: "CNY", Side: SideSell, Amount: decimal.NewFromInt(5000)})
+func (o *OKX) BestPrice(ctx context.Context, q BookQuery) (*Exchange, error) {
+	q.Offset, q.Limit = 0, 100
+	exchanges, err := o.GetBook(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if len(exchanges) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s side=%s amount=%s", ErrNoAds, q.Base, q.Quote, q.Side, q.Amount)
+	}
+	return exchanges[0], nil
+}
+
+// GetTop10Exchanges 获取前10交易商家的 C2C 汇率 可指定不同的货币和支付方式
+func (o *OKX) GetTop10Exchanges(baseCurrency, quoteCurrency string, okxPayMethod PayMethod) ([]*Exchange, error) {
+	return o.GetBook(context.Background(), BookQuery{Base: baseCurrency, Quote: quoteCurrency, PayMethod: okxPayMethod})
+}
+
 // GetUsdtCnyExchangeList 获取usdt到cny的前10个实时汇率结果列表
 func (o *OKX) GetUsdtCnyExchangeList(okxPayMethod PayMethod) ([]*Exchange, error) {
 	return o.GetTop10Exchanges("USDT", "CNY", okxPayMethod)
 }
 
 // GetUsdtCnyRateOnly 获取实时人民币到USDT的汇率
+//
+// Deprecated: 出错或没有报价时返回 decimal.Zero，调用方无法区分。请使用 rates.Oracle 配合 rates.OKXC2CSource。
 func (o *OKX) GetUsdtCnyRateOnly(okxPayMethod PayMethod) decimal.Decimal {
 	usdtRates, err := o.GetUsdtCnyExchangeList(okxPayMethod)
-	if err != nil {
+	if err != nil || len(usdtRates) == 0 {
 		return decimal.Zero
 	}
 	var total decimal.Decimal
 	for _, usdtRate := range usdtRates {
-		price, err := decimal.NewFromString(usdtRate.Price)
-		if err != nil {
-			continue
-		}
-		total = total.Add(price)
+		total = total.Add(usdtRate.PriceValue)
 	}
 	return total.DivRound(decimal.NewFromInt(int64(len(usdtRates))), 2)
 }
 
-func (o *OKX) doRequest(url string) (string, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (o *OKX) doRequest(ctx context.Context, pathAndQuery string, out *DataResponse) error {
+	base := o.BaseURL
+	if base == "" {
+		base = defaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+pathAndQuery, nil)
 	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return "", err
+		return fmt.Errorf("okx: create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36")
 	req.Header.Set("App-Type", "web")
 
-	res, err := o.Client.Do(req)
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
 	if err != nil {
-		log.Printf("Error performing request: %v", err)
-		return "", err
+		return fmt.Errorf("okx: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		log.Printf("API response error: %s", res.Status)
-		return "", fmt.Errorf("API responded with status code: %d", res.StatusCode)
-	}
-
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Printf("Error reading response body: %v", err)
-		return "", err
+		return fmt.Errorf("okx: read response: %w", err)
 	}
-
-	return string(body), nil
+	if res.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: res.StatusCode, Msg: res.Status}
+		if json.Unmarshal(body, out) == nil && out.Msg != "" {
+			apiErr.Code, apiErr.Msg = out.Code, out.Msg
+		}
+		return apiErr
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("okx: decode response: %w", err)
+	}
+	if out.Code != 0 {
+		msg := out.Msg
+		if msg == "" {
+			msg = out.ErrorMessage
+		}
+		return &APIError{StatusCode: res.StatusCode, Code: out.Code, Msg: msg}
+	}
+	return nil
 }
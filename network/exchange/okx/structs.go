@@ -1,9 +1,38 @@
 package okx
 
+import "github.com/shopspring/decimal"
+
+// Exchange 一条 C2C 广告。金额字段均以法币（Quote）计，AvailableAmount 以数字货币（Base）计。
 type Exchange struct {
-	Currency string
-	ShopName string
-	Price    string
+	ID       string
+	Side     Side
+	Currency string // 数字货币，如 "USDT"
+	Quote    string // 法币，如 "CNY"
+	Price    string // 接口返回的价格原文
+	// PriceValue Price 的数值，排序与金额校验都以它为准；无法解析时为零
+	PriceValue decimal.Decimal
+
+	AvailableAmount decimal.Decimal // 剩余可交易的数字货币数量
+	MinAmount       decimal.Decimal // 单笔最小法币金额
+	MaxAmount       decimal.Decimal // 单笔最大法币金额
+	PaymentMethods  []string
+	PaymentTimeout  int // 付款时限，分钟
+
+	// 商家信息
+	ShopName        string
+	MerchantID      string
+	CompletedOrders int
+	CancelledOrders int
+	CompletionRate  decimal.Decimal // 0~1
+	Institution     bool
+}
+
+// Accepts 该广告能否完成 amount 法币的一笔交易：在单笔限额内，且剩余数量足够。
+func (e *Exchange) Accepts(amount decimal.Decimal) bool {
+	if amount.LessThan(e.MinAmount) || (e.MaxAmount.IsPositive() && amount.GreaterThan(e.MaxAmount)) {
+		return false
+	}
+	return e.AvailableAmount.Mul(e.PriceValue).GreaterThanOrEqual(amount)
 }
 
 type DataResponse struct {
@@ -17,9 +46,9 @@ type DataResponse struct {
 }
 
 type Data struct {
-	Buy       []interface{} `json:"buy"`
-	Recommend Recommend     `json:"recommend"`
-	Sell      []SellItem    `json:"sell"`
+	Buy       []AdItem  `json:"buy"`
+	Recommend Recommend `json:"recommend"`
+	Sell      []AdItem  `json:"sell"`
 }
 
 type Recommend struct {
@@ -27,7 +56,10 @@ type Recommend struct {
 	UserGroup     int         `json:"userGroup"`
 }
 
-type SellItem struct {
+// SellItem 保留旧名称，买卖两侧的广告结构相同。
+type SellItem = AdItem
+
+type AdItem struct {
 	AlreadyTraded             bool        `json:"alreadyTraded"`
 	AvailableAmount           string      `json:"availableAmount"`
 	BaseCurrency              string      `json:"baseCurrency"`
@@ -67,3 +99,34 @@ type SellItem struct {
 	VerificationType          int         `json:"verificationType"`
 	WhitelistedCountries      []string    `json:"whitelistedCountries"`
 }
+
+func (v AdItem) toExchange() *Exchange {
+	return &Exchange{
+		ID:              v.ID,
+		Side:            Side(v.Side),
+		Currency:        v.BaseCurrency,
+		Quote:           v.QuoteCurrency,
+		Price:           v.Price,
+		PriceValue:      parseDecimal(v.Price),
+		AvailableAmount: parseDecimal(v.AvailableAmount),
+		MinAmount:       parseDecimal(v.QuoteMinAmountPerOrder),
+		MaxAmount:       parseDecimal(v.QuoteMaxAmountPerOrder),
+		PaymentMethods:  v.PaymentMethods,
+		PaymentTimeout:  v.PaymentTimeoutMinutes,
+		ShopName:        v.NickName,
+		MerchantID:      v.MerchantID,
+		CompletedOrders: v.CompletedOrderQuantity,
+		CancelledOrders: v.CancelledOrderQuantity,
+		CompletionRate:  parseDecimal(v.CompletedRate),
+		Institution:     v.IsInstitution == 1,
+	}
+}
+
+// parseDecimal 接口的数值字段可能为空串，解析失败时返回零。
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
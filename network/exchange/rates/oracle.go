@@ -0,0 +1,221 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultMaxAge = 5 * time.Minute
+	defaultTTL    = 30 * time.Second
+)
+
+var defaultMaxDeviation = decimal.NewFromFloat(0.02)
+
+// Rate 聚合后的汇率。
+type Rate struct {
+	Base      string
+	Quote     string
+	Price     decimal.Decimal
+	Time      time.Time // 参与聚合的报价中最早的时间
+	Quotes    []Quote   // 参与聚合的报价
+	Rejected  []Quote   // 因偏离过大被剔除的报价
+	FetchedAt time.Time // 询价完成的时间
+}
+
+// Oracle 并发询价 Sources 并聚合；Sources 给不出结果时依次尝试 Fallbacks，取第一个有效报价。
+// Oracle 本身也实现了 PriceSource，可以作为另一个 Oracle 的数据源或后备。并发安全。
+type Oracle struct {
+	Sources   []PriceSource
+	Fallbacks []PriceSource
+
+	Method       Method
+	MaxDeviation decimal.Decimal // 相对中位数的最大偏离，默认 0.02
+	MinSources   int             // 剔除异常后至少需要的报价数，默认 1
+	MaxAge       time.Duration   // 报价的最长有效期，默认 5 分钟；询价失败时，不超过该时长的缓存结果仍会返回
+	TTL          time.Duration   // 缓存时长，默认 30 秒
+
+	mu    sync.Mutex
+	cache map[string]Rate
+}
+
+func NewOracle(sources ...PriceSource) *Oracle {
+	return &Oracle{
+		Sources:      sources,
+		Method:       Median,
+		MaxDeviation: defaultMaxDeviation,
+		MinSources:   1,
+		MaxAge:       defaultMaxAge,
+		TTL:          defaultTTL,
+	}
+}
+
+func (o *Oracle) Name() string {
+	return "oracle"
+}
+
+// Price 实现 PriceSource，Volume 为参与聚合的报价成交量之和。
+func (o *Oracle) Price(ctx context.Context, base, quote string) (Quote, error) {
+	r, err := o.Rate(ctx, base, quote)
+	if err != nil {
+		return Quote{}, err
+	}
+	q := Quote{Source: o.Name(), Base: r.Base, Quote: r.Quote, Price: r.Price, Time: r.Time}
+	for _, s := range r.Quotes {
+		q.Volume = q.Volume.Add(s.Volume)
+	}
+	return q, nil
+}
+
+// Rate 查询 base/quote 汇率，TTL 内直接返回缓存；未配置任何数据源时返回 ErrNoSources。
+func (o *Oracle) Rate(ctx context.Context, base, quote string) (Rate, error) {
+	if len(o.Sources) == 0 && len(o.Fallbacks) == 0 {
+		return Rate{}, ErrNoSources
+	}
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	key := base + "/" + quote
+
+	o.mu.Lock()
+	cached, ok := o.cache[key]
+	o.mu.Unlock()
+	if ok && time.Since(cached.FetchedAt) < o.ttl() {
+		return cached, nil
+	}
+
+	r, err := o.fetch(ctx, base, quote)
+	if err != nil {
+		if ok && time.Since(cached.Time) <= o.maxAge() {
+			return cached, nil
+		}
+		return Rate{}, err
+	}
+	o.mu.Lock()
+	if o.cache == nil {
+		o.cache = make(map[string]Rate)
+	}
+	o.cache[key] = r
+	o.mu.Unlock()
+	return r, nil
+}
+
+// Convert 把 amount 个 base 换算为 quote；数据源不提供 base/quote 时尝试以 quote/base 的倒数换算。
+func (o *Oracle) Convert(ctx context.Context, amount decimal.Decimal, base, quote string) (decimal.Decimal, error) {
+	if strings.EqualFold(base, quote) {
+		return amount, nil
+	}
+	r, err := o.Rate(ctx, base, quote)
+	if err == nil {
+		return amount.Mul(r.Price), nil
+	}
+	inv, invErr := o.Rate(ctx, quote, base)
+	if invErr != nil {
+		return decimal.Zero, err
+	}
+	return amount.Div(inv.Price), nil
+}
+
+func (o *Oracle) fetch(ctx context.Context, base, quote string) (Rate, error) {
+	quotes, errs := o.collect(ctx, base, quote)
+	kept, rejected := RejectOutliers(quotes, o.MaxDeviation)
+	minSources := max(o.MinSources, 1)
+	if len(kept) >= minSources {
+		price, err := Aggregate(kept, o.Method)
+		if err != nil {
+			return Rate{}, err
+		}
+		return o.newRate(base, quote, price, kept, rejected), nil
+	}
+	if len(rejected) > 0 {
+		errs = append(errs, fmt.Errorf("rates: %d of %d quotes rejected as outliers", len(rejected), len(quotes)))
+	} else if len(o.Sources) > 0 {
+		errs = append(errs, fmt.Errorf("rates: got %d valid quotes, need %d", len(kept), minSources))
+	}
+
+	for _, src := range o.Fallbacks {
+		q, err := o.query(ctx, src, base, quote)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return o.newRate(base, quote, q.Price, []Quote{q}, rejected), nil
+	}
+	cause := errors.Join(errs...)
+	if cause == nil {
+		return Rate{}, fmt.Errorf("%w for %s/%s", ErrNoPrice, base, quote)
+	}
+	return Rate{}, fmt.Errorf("%w for %s/%s: %w", ErrNoPrice, base, quote, cause)
+}
+
+// collect 并发询价所有 Sources，返回通过校验的报价与各数据源的错误。
+func (o *Oracle) collect(ctx context.Context, base, quote string) ([]Quote, []error) {
+	quotes := make([]Quote, len(o.Sources))
+	errs := make([]error, len(o.Sources))
+	var wg sync.WaitGroup
+	for i, src := range o.Sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			quotes[i], errs[i] = o.query(ctx, src, base, quote)
+		}()
+	}
+	wg.Wait()
+
+	var valid []Quote
+	var failed []error
+	for i := range o.Sources {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+		} else {
+			valid = append(valid, quotes[i])
+		}
+	}
+	return valid, failed
+}
+
+// query 询价并校验：价格必须为正，报价时间不得超过 MaxAge。
+func (o *Oracle) query(ctx context.Context, src PriceSource, base, quote string) (Quote, error) {
+	q, err := src.Price(ctx, base, quote)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%s: %w", src.Name(), err)
+	}
+	if !q.Price.IsPositive() {
+		return Quote{}, fmt.Errorf("%s: %w: %s", src.Name(), ErrInvalidPrice, q.Price)
+	}
+	if age := time.Since(q.Time); q.Time.IsZero() || age > o.maxAge() {
+		return Quote{}, fmt.Errorf("%s: %w: quoted %s ago", src.Name(), ErrStale, age.Round(time.Second))
+	}
+	return q, nil
+}
+
+func (o *Oracle) newRate(base, quote string, price decimal.Decimal, quotes, rejected []Quote) Rate {
+	oldest := quotes[0].Time
+	for _, q := range quotes[1:] {
+		if q.Time.Before(oldest) {
+			oldest = q.Time
+		}
+	}
+	return Rate{
+		Base: base, Quote: quote, Price: price, Time: oldest,
+		Quotes: quotes, Rejected: rejected, FetchedAt: time.Now(),
+	}
+}
+
+func (o *Oracle) ttl() time.Duration {
+	if o.TTL <= 0 {
+		return defaultTTL
+	}
+	return o.TTL
+}
+
+func (o *Oracle) maxAge() time.Duration {
+	if o.MaxAge <= 0 {
+		return defaultMaxAge
+	}
+	return o.MaxAge
+}
@@ -0,0 +1,128 @@
+// Package rates 汇总多个交易所的报价，给出可靠的汇率。
+//
+// 单一数据源随时可能超时、返回异常价格或长时间不更新，因此 Oracle 同时询价多个 PriceSource，
+// 剔除偏离中位数过大的报价后按中位数或成交量加权均价（VWAP）聚合，并带有过期检查、TTL 缓存与后备数据源。
+// 任何情况下都不会返回零价格：拿不到可用报价时返回错误。
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNoPrice 没有可用的报价。
+	ErrNoPrice = errors.New("rates: no price available")
+	// ErrUnsupportedPair 数据源不支持该交易对。
+	ErrUnsupportedPair = errors.New("rates: unsupported pair")
+	// ErrStale 报价时间超过 MaxAge。
+	ErrStale = errors.New("rates: stale quote")
+	// ErrInvalidPrice 报价为零或负数。
+	ErrInvalidPrice = errors.New("rates: invalid price")
+	// ErrNoSources Oracle 既没有 Sources 也没有 Fallbacks。
+	ErrNoSources = errors.New("rates: oracle has no price sources")
+)
+
+// Quote 一个数据源给出的报价：1 Base = Price Quote。
+type Quote struct {
+	Source string
+	Base   string
+	Quote  string
+	Price  decimal.Decimal
+	Volume decimal.Decimal // 以 Base 计的成交量或挂单量，用作 VWAP 权重；未知时为零
+	Time   time.Time       // 报价对应的时间
+}
+
+// PriceSource 报价数据源。
+type PriceSource interface {
+	// Name 数据源名称，出现在 Quote.Source 与错误信息中。
+	Name() string
+	// Price 查询 base/quote 的价格，不支持的交易对返回包装了 ErrUnsupportedPair 的错误。
+	Price(ctx context.Context, base, quote string) (Quote, error)
+}
+
+// Method 聚合方式。
+type Method int
+
+const (
+	// Median 取中位数，对单个异常报价不敏感。
+	Median Method = iota
+	// VWAP 按 Volume 加权平均；所有报价都没有成交量时退化为中位数。
+	VWAP
+)
+
+// Aggregate 按 method 聚合报价，quotes 为空时返回 ErrNoPrice。
+func Aggregate(quotes []Quote, method Method) (decimal.Decimal, error) {
+	if len(quotes) == 0 {
+		return decimal.Zero, ErrNoPrice
+	}
+	switch method {
+	case Median:
+		return median(quotes), nil
+	case VWAP:
+		return vwap(quotes), nil
+	default:
+		return decimal.Zero, fmt.Errorf("rates: unknown aggregation method %d", method)
+	}
+}
+
+func median(quotes []Quote) decimal.Decimal {
+	prices := make([]decimal.Decimal, len(quotes))
+	for i, q := range quotes {
+		prices[i] = q.Price
+	}
+	slices.SortFunc(prices, func(a, b decimal.Decimal) int { return a.Cmp(b) })
+	mid := len(prices) / 2
+	if len(prices)%2 == 1 {
+		return prices[mid]
+	}
+	return prices[mid-1].Add(prices[mid]).Div(decimal.NewFromInt(2))
+}
+
+func vwap(quotes []Quote) decimal.Decimal {
+	var notional, volume decimal.Decimal
+	for _, q := range quotes {
+		if q.Volume.IsPositive() {
+			notional = notional.Add(q.Price.Mul(q.Volume))
+			volume = volume.Add(q.Volume)
+		}
+	}
+	if volume.IsZero() {
+		return median(quotes)
+	}
+	return notional.Div(volume)
+}
+
+// RejectOutliers 以中位数为基准，剔除相对偏离超过 maxDeviation（如 0.02 表示 2%）的报价。
+// maxDeviation 不大于零时不做剔除。只有两个报价且相互偏离过大时二者都会被剔除——无法判断哪一个是对的。
+// 价格不大于零的报价总是被剔除，也不参与中位数计算，因此中位数不会为零。
+func RejectOutliers(quotes []Quote, maxDeviation decimal.Decimal) (kept, rejected []Quote) {
+	if len(quotes) == 0 || !maxDeviation.IsPositive() {
+		return quotes, nil
+	}
+	valid := make([]Quote, 0, len(quotes))
+	for _, q := range quotes {
+		if q.Price.IsPositive() {
+			valid = append(valid, q)
+		} else {
+			rejected = append(rejected, q)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, rejected
+	}
+	m := median(valid)
+	for _, q := range valid {
+		if q.Price.Sub(m).Abs().Div(m).GreaterThan(maxDeviation) {
+			rejected = append(rejected, q)
+		} else {
+			kept = append(kept, q)
+		}
+	}
+	return kept, rejected
+}
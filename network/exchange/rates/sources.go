@@ -0,0 +1,114 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+	"github.com/bizvip/go-utils/network/exchange/okx"
+)
+
+// -----------------------------
+// Binance 现货
+// -----------------------------
+
+// codeInvalidSymbol Binance 对不存在的交易对返回 -1121。
+const codeInvalidSymbol = -1121
+
+// BinanceSource 以 Binance 现货最新成交价报价，交易对为 base+quote，如 BTC/USDT → BTCUSDT。
+// 使用 24hr MINI 统计而不是 /ticker/price，以便拿到成交量作为 VWAP 权重。
+type BinanceSource struct {
+	Market *binance.MarketService
+}
+
+func NewBinanceSource(m *binance.MarketService) *BinanceSource {
+	if m == nil {
+		m = binance.NewMarketService()
+	}
+	return &BinanceSource{Market: m}
+}
+
+func (s *BinanceSource) Name() string {
+	return "binance"
+}
+
+func (s *BinanceSource) Price(ctx context.Context, base, quote string) (Quote, error) {
+	symbol := strings.ToUpper(base + quote)
+	stats, err := s.Market.GetTicker24Hr(ctx, []string{symbol}, binance.TickerMini)
+	if err != nil {
+		var apiErr *binance.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == codeInvalidSymbol {
+			return Quote{}, fmt.Errorf("%w: %s", ErrUnsupportedPair, symbol)
+		}
+		return Quote{}, err
+	}
+	if len(stats) == 0 {
+		return Quote{}, fmt.Errorf("%w: %s", ErrUnsupportedPair, symbol)
+	}
+	st := stats[0]
+	return Quote{
+		Source: s.Name(),
+		Base:   strings.ToUpper(base),
+		Quote:  strings.ToUpper(quote),
+		Price:  st.LastPrice,
+		Volume: st.Volume,
+		Time:   time.UnixMilli(st.CloseTime),
+	}, nil
+}
+
+// -----------------------------
+// OKX C2C
+// -----------------------------
+
+// OKXC2CSource 以 OKX C2C 广告报价，适用于 USDT/CNY 这类法币交易对。
+// 单个商家的报价差异较大，取排名靠前商家价格的中位数，Volume 为这些广告的可用数量之和。
+type OKXC2CSource struct {
+	OKX       *okx.OKX
+	Side      okx.Side        // 默认 okx.SideSell，即买入数字货币的价格
+	PayMethod okx.PayMethod   // 默认 PayMethodAll
+	Amount    decimal.Decimal // 大于零时只统计能完成该法币金额的广告
+	Depth     int             // 参与统计的广告数，默认 10
+}
+
+func NewOKXC2CSource(o *okx.OKX) *OKXC2CSource {
+	if o == nil {
+		o = okx.NewOkxExchangeService()
+	}
+	return &OKXC2CSource{OKX: o, Side: okx.SideSell, PayMethod: okx.PayMethodAll}
+}
+
+func (s *OKXC2CSource) Name() string {
+	return "okx-c2c"
+}
+
+func (s *OKXC2CSource) Price(ctx context.Context, base, quote string) (Quote, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	ads, err := s.OKX.GetBook(ctx, okx.BookQuery{
+		Base: base, Quote: quote, Side: s.Side, PayMethod: s.PayMethod, Amount: s.Amount, Limit: s.Depth,
+	})
+	if err != nil {
+		return Quote{}, err
+	}
+	if len(ads) == 0 {
+		return Quote{}, fmt.Errorf("%w: no C2C ads for %s/%s", ErrNoPrice, base, quote)
+	}
+	quotes := make([]Quote, len(ads))
+	var volume decimal.Decimal
+	for i, ad := range ads {
+		quotes[i] = Quote{Price: ad.PriceValue}
+		volume = volume.Add(ad.AvailableAmount)
+	}
+	return Quote{
+		Source: s.Name(),
+		Base:   base,
+		Quote:  quote,
+		Price:  median(quotes),
+		Volume: volume,
+		Time:   time.Now(),
+	}, nil
+}
@@ -1,7 +1,14 @@
 package os
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -9,11 +16,102 @@ const (
 	MB = 1024 * KB
 	GB = 1024 * MB
 	TB = 1024 * GB
+	PB = 1024 * TB
+)
+
+// IEC 二进制单位，与 KB/MB/GB/TB 相同，名称更明确。
+const (
+	KiB = KB
+	MiB = MB
+	GiB = GB
+	TiB = TB
+	PiB = PB
 )
 
+// SI 十进制单位，对应 ParseByteSize 中的 KB / MB / GB / TB / PB。
+const (
+	KBSI = 1000
+	MBSI = 1000 * KBSI
+	GBSI = 1000 * MBSI
+	TBSI = 1000 * GBSI
+	PBSI = 1000 * TBSI
+)
+
+// ErrInvalidByteSize 无法解析的字节大小。
+var ErrInvalidByteSize = errors.New("invalid byte size")
+
 // ByteSize 字节大小转换辅助函数
+//
+// 实现了 encoding.TextUnmarshaler、json.Unmarshaler 与 yaml.Unmarshaler，
+// 可直接作为 configer 加载的配置字段，写作 "200MB"、"1.5GiB"、"10k" 或整数字节数。
 type ByteSize int64
 
+// byteUnits 单位后缀（小写）到字节数：
+//   - IEC：KiB / MiB / ...（简写 Ki / Mi / ...）按 1024 进位
+//   - SI：KB / MB / ...（B 结尾）按 1000 进位
+//   - 单字母简写 k / m / g / t / p 沿用本包 KB = 1024 的约定，按 1024 进位，与 nginx 等配置写法一致
+var byteUnits = map[string]int64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+
+	"k": KiB, "ki": KiB, "kib": KiB,
+	"m": MiB, "mi": MiB, "mib": MiB,
+	"g": GiB, "gi": GiB, "gib": GiB,
+	"t": TiB, "ti": TiB, "tib": TiB,
+	"p": PiB, "pi": PiB, "pib": PiB,
+
+	"kb": KBSI, "mb": MBSI, "gb": GBSI, "tb": TBSI, "pb": PBSI,
+}
+
+// ParseByteSize 解析人类可读的字节大小，单位不区分大小写，数字与单位之间可有空格：
+//
+//	"1024" → 1024、"10k" → 10240、"200MB" → 200000000、"1.5GiB" → 1610612736、"-5B" → -5
+//
+// 可以解析 String 与 MarshalText 的输出。小数换算后不足 1 字节的部分舍去；超出 int64 的值返回 ErrInvalidByteSize。
+func ParseByteSize(s string) (ByteSize, error) {
+	text := strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = text[:1], text[1:]
+	}
+	i := strings.IndexFunc(text, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(text)
+	}
+	number, unit := text[:i], strings.ToLower(strings.TrimSpace(text[i:]))
+	if number == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, s)
+	}
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit in %q", ErrInvalidByteSize, s)
+	}
+	d, err := decimal.NewFromString(sign + number)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, s)
+	}
+	bytes := d.Mul(decimal.NewFromInt(multiplier)).Truncate(0)
+	if bytes.GreaterThan(decimal.NewFromInt(math.MaxInt64)) || bytes.LessThan(decimal.NewFromInt(math.MinInt64)) {
+		return 0, fmt.Errorf("%w: %q overflows int64", ErrInvalidByteSize, s)
+	}
+	return ByteSize(bytes.IntPart()), nil
+}
+
+// MustParseByteSize 同 ParseByteSize，出错时 panic，用于常量形式的默认值。
+func MustParseByteSize(s string) ByteSize {
+	b, err := ParseByteSize(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Bytes 字节数。
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
+
 func (b ByteSize) ToKB() float64 {
 	return float64(b) / KB
 }
@@ -26,17 +124,89 @@ func (b ByteSize) ToGB() float64 {
 	return float64(b) / GB
 }
 
+// String 人类可读的写法，按 1024 进位并使用 IEC 单位名，保留两位小数，如 "1.50 MiB"。
+// 仅用于展示：ParseByteSize 能解析回近似值，但精度会丢失，序列化请用 MarshalText。
 func (b ByteSize) String() string {
 	switch {
 	case b >= TB:
-		return fmt.Sprintf("%.2f TB", b.ToGB()/1024)
+		return fmt.Sprintf("%.2f TiB", b.ToGB()/1024)
 	case b >= GB:
-		return fmt.Sprintf("%.2f GB", b.ToGB())
+		return fmt.Sprintf("%.2f GiB", b.ToGB())
 	case b >= MB:
-		return fmt.Sprintf("%.2f MB", b.ToMB())
+		return fmt.Sprintf("%.2f MiB", b.ToMB())
 	case b >= KB:
-		return fmt.Sprintf("%.2f KB", b.ToKB())
+		return fmt.Sprintf("%.2f KiB", b.ToKB())
 	default:
 		return fmt.Sprintf("%d B", b)
 	}
 }
+
+// -----------------------------
+// 文本 / JSON / YAML
+// -----------------------------
+
+// iecUnits MarshalText 使用的单位，从大到小。
+var iecUnits = []struct {
+	name string
+	size int64
+}{
+	{"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+}
+
+// MarshalText 无损的 IEC 写法：选用能以至多 3 位小数精确表示的最大单位，
+// 如 1610612736 → "1.5GiB"，1000 → "1000B"，-1024 → "-1KiB"。String 保留两位小数、会丢失精度，不用于序列化。
+func (b ByteSize) MarshalText() ([]byte, error) {
+	sign, n := "", int64(b)
+	if n < 0 && n != math.MinInt64 {
+		sign, n = "-", -n
+	}
+	if n > 0 {
+		for _, u := range iecUnits {
+			whole, rem := n/u.size, n%u.size
+			if whole == 0 || rem*1000%u.size != 0 {
+				continue
+			}
+			s := sign + strconv.FormatInt(whole, 10)
+			if rem != 0 {
+				frac := strings.TrimRight(fmt.Sprintf("%03d", rem*1000/u.size), "0")
+				s += "." + frac
+			}
+			return []byte(s + u.name), nil
+		}
+	}
+	return []byte(strconv.FormatInt(int64(b), 10) + "B"), nil
+}
+
+// UnmarshalText 见 ParseByteSize。
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	v, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// UnmarshalJSON 同时接受字符串 "200MB" 与数字 1048576（字节）；null 保持原值。
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidByteSize, s)
+		}
+		s = unquoted
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// UnmarshalYAML 同时接受字符串与整数；序列化走 MarshalText。
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%w: expected a scalar at line %d", ErrInvalidByteSize, node.Line)
+	}
+	return b.UnmarshalText([]byte(node.Value))
+}
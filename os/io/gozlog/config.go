@@ -1,6 +1,10 @@
 package gozlog
 
-import "time"
+import (
+	"time"
+
+	osx "github.com/bizvip/go-utils/os"
+)
 
 type Level string
 
@@ -23,7 +27,8 @@ type Config struct {
 	TimeFormat      string            `toml:"time_format" json:"time_format"`
 	NoColor         bool              `toml:"no_color" json:"no_color"`
 	MaxAge          int               `toml:"max_age" json:"max_age"`
-	MaxSize         int               `toml:"max_size" json:"max_size"`
+	MaxSize         int               `toml:"max_size" json:"max_size"`           // 单个日志文件上限，单位 MiB
+	MaxFileSize     osx.ByteSize      `toml:"max_file_size" json:"max_file_size"` // 同 MaxSize，非 0 时优先于 MaxSize；"200MiB" 即 200，"200MB" 为 SI 的 2 亿字节，向上取整为 191
 	MaxBackups      int               `toml:"max_backups" json:"max_backups"`
 	Compress        bool              `toml:"compress" json:"compress"`
 	Caller          bool              `toml:"caller" json:"caller"`
@@ -71,6 +76,10 @@ func (c Config) normalized() Config {
 	if cfg.MaxAge <= 0 {
 		cfg.MaxAge = def.MaxAge
 	}
+	if cfg.MaxFileSize > 0 {
+		// lumberjack 以 MB 为单位，不足 1MB 按 1MB 计
+		cfg.MaxSize = int((cfg.MaxFileSize + osx.MB - 1) / osx.MB)
+	}
 	if cfg.MaxSize <= 0 {
 		cfg.MaxSize = def.MaxSize
 	}
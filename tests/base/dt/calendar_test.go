@@ -0,0 +1,156 @@
+package dt_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bizvip/go-utils/base/dt"
+)
+
+// 2025 年国庆、中秋放假安排：10 月 1 日至 8 日放假，9 月 28 日（周日）、10 月 11 日（周六）上班。
+func chinaCalendar(t *testing.T) *dt.Calendar {
+	t.Helper()
+	cal, err := dt.NewCalendar(mustLoc(t, "Asia/Shanghai"),
+		dt.WithHolidays("2025-10-01", "2025-10-02", "2025-10-03", "2025-10-06", "2025-10-07", "2025-10-08"),
+		dt.WithWorkdays("2025-09-28", "2025-10-11"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestCalendarBoundaries(t *testing.T) {
+	cal := chinaCalendar(t)
+	sh := cal.Location()
+	// UTC 10 月 31 日 20:00 在上海已是 11 月 1 日（周六）
+	ts := time.Date(2025, 10, 31, 20, 0, 0, 0, time.UTC)
+
+	if got := cal.StartOfDay(ts); !got.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, sh)) {
+		t.Fatalf("StartOfDay = %v", got)
+	}
+	if got := cal.EndOfDay(ts); !got.Equal(time.Date(2025, 11, 1, 23, 59, 59, 999999999, sh)) {
+		t.Fatalf("EndOfDay = %v", got)
+	}
+	if got := cal.StartOfWeek(ts); !got.Equal(time.Date(2025, 10, 27, 0, 0, 0, 0, sh)) {
+		t.Fatalf("StartOfWeek = %v", got)
+	}
+	if got := cal.EndOfWeek(ts); !got.Equal(time.Date(2025, 11, 2, 23, 59, 59, 999999999, sh)) {
+		t.Fatalf("EndOfWeek = %v", got)
+	}
+	if got := cal.StartOfMonth(ts); !got.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, sh)) {
+		t.Fatalf("StartOfMonth = %v", got)
+	}
+	if got := cal.EndOfMonth(ts); !got.Equal(time.Date(2025, 11, 30, 23, 59, 59, 999999999, sh)) {
+		t.Fatalf("EndOfMonth = %v", got)
+	}
+
+	sunday, _ := dt.NewCalendar(time.UTC, dt.WithWeekStart(time.Sunday))
+	if got := sunday.StartOfWeek(ts); !got.Equal(time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("StartOfWeek(Sunday) = %v", got)
+	}
+}
+
+func TestCalendarParseRangeWeekStart(t *testing.T) {
+	cal, _ := dt.NewCalendar(mustLoc(t, "Asia/Shanghai"), dt.WithWeekStart(time.Sunday))
+	from, to, err := cal.ParseRange("this_week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if now := cal.Now(); from.Weekday() != time.Sunday || !from.Equal(cal.StartOfWeek(from)) ||
+		!to.Equal(from.AddDate(0, 0, 7)) || now.Before(from) || !now.Before(to) {
+		t.Fatalf("this_week = [%v, %v)", from, to)
+	}
+	lastFrom, lastTo, err := cal.ParseRange("last_week")
+	if err != nil || !lastTo.Equal(from) || !lastFrom.Equal(from.AddDate(0, 0, -7)) {
+		t.Fatalf("last_week = [%v, %v), %v", lastFrom, lastTo, err)
+	}
+	if relFrom, _, err := cal.ParseRange("now/w..now"); err != nil || !relFrom.Equal(from) {
+		t.Fatalf("now/w = %v, %v", relFrom, err)
+	}
+}
+
+func TestCalendarISOWeekAndMillis(t *testing.T) {
+	cal := chinaCalendar(t)
+	// 2024-12-30 是 2025 年第 1 周
+	if y, w := cal.ISOWeek(cal.Date(2024, 12, 30)); y != 2025 || w != 1 {
+		t.Fatalf("ISOWeek = %d-%d", y, w)
+	}
+	// UTC 2020-12-31 20:00 在上海已是 2021-01-01（周五），属于 2020 年第 53 周
+	if y, w := cal.ISOWeek(time.Date(2020, 12, 31, 20, 0, 0, 0, time.UTC)); y != 2020 || w != 53 {
+		t.Fatalf("ISOWeek across zone = %d-%d", y, w)
+	}
+
+	const ms = 1735660800000 // 2024-12-31T16:00:00Z
+	if got := cal.FormatMillis(ms, ""); got != "2025-01-01 00:00:00" {
+		t.Fatalf("FormatMillis = %s", got)
+	}
+	utc, _ := dt.NewCalendar(nil)
+	if got := utc.FormatMillis(ms, time.DateOnly); got != "2024-12-31" {
+		t.Fatalf("FormatMillis UTC = %s", got)
+	}
+	if got, err := cal.ParseMillis("1735660800000"); err != nil || got.Location() != cal.Location() || got.UnixMilli() != ms {
+		t.Fatalf("ParseMillis = %v, %v", got, err)
+	}
+	if _, err := cal.ParseMillis("abc"); err == nil {
+		t.Fatal("ParseMillis accepted garbage")
+	}
+	if time.Local == cal.Location() {
+		t.Fatal("calendar changed time.Local")
+	}
+}
+
+func TestCalendarBusinessDays(t *testing.T) {
+	cal := chinaCalendar(t)
+	d := func(m time.Month, day int) time.Time { return cal.Date(2025, m, day) }
+
+	if !cal.IsBusinessDay(d(9, 28)) || !cal.IsWeekend(d(9, 28)) {
+		t.Fatal("adjusted Sunday should be a business day")
+	}
+	if cal.IsBusinessDay(d(10, 8)) || !cal.IsHoliday(d(10, 8)) {
+		t.Fatal("Oct 8 should be a holiday")
+	}
+
+	// 9 月 30 日（周二）下午加 1 个工作日跳过整个假期，到 10 月 9 日，保留时刻
+	start := d(9, 30).Add(15 * time.Hour)
+	if got := cal.AddBusinessDays(start, 1); !got.Equal(d(10, 9).Add(15 * time.Hour)) {
+		t.Fatalf("AddBusinessDays = %v", got)
+	}
+	// 10 月 9 日、10 日、11 日（调休周六）
+	if got := cal.AddBusinessDays(d(10, 8), 3); !got.Equal(d(10, 11)) {
+		t.Fatalf("AddBusinessDays over adjusted Saturday = %v", got)
+	}
+	if got := cal.AddBusinessDays(d(10, 9), -1); !got.Equal(d(9, 30)) {
+		t.Fatalf("AddBusinessDays backwards = %v", got)
+	}
+	if got := cal.NextBusinessDay(d(10, 4)); !got.Equal(d(10, 9)) {
+		t.Fatalf("NextBusinessDay = %v", got)
+	}
+
+	// 9 月 22 日至 10 月 13 日：三周共 15 个平日，减去 6 天假期，加上 2 天调休
+	if n := cal.BusinessDaysBetween(d(9, 22), d(10, 13)); n != 11 {
+		t.Fatalf("BusinessDaysBetween = %d", n)
+	}
+	if n := cal.BusinessDaysBetween(d(10, 13), d(9, 22)); n != -11 {
+		t.Fatalf("BusinessDaysBetween reversed = %d", n)
+	}
+
+	gulf, _ := dt.NewCalendar(time.UTC, dt.WithWeekend(time.Friday, time.Saturday))
+	if gulf.IsBusinessDay(time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC)) || !gulf.IsBusinessDay(time.Date(2025, 10, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Friday/Saturday weekend")
+	}
+}
+
+func TestCalendarInvalid(t *testing.T) {
+	if _, err := dt.NewCalendar(nil, dt.WithHolidays("2025/10/01")); !errors.Is(err, dt.ErrInvalidCalendar) {
+		t.Fatalf("bad holiday: %v", err)
+	}
+	all := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	if _, err := dt.NewCalendar(nil, dt.WithWeekend(all...)); !errors.Is(err, dt.ErrInvalidCalendar) {
+		t.Fatalf("all weekend: %v", err)
+	}
+	if _, err := dt.LoadCalendar("Mars/Olympus"); !errors.Is(err, dt.ErrInvalidCalendar) {
+		t.Fatalf("bad zone: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package dt_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bizvip/go-utils/base/dt"
+)
+
+func mustLoc(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseSpan(t *testing.T) {
+	cases := []struct {
+		in   string
+		want dt.Span
+	}{
+		{"1d2h", dt.Span{Days: 1, Duration: 2 * time.Hour}},
+		{"2w", dt.Span{Days: 14}},
+		{"3mo", dt.Span{Months: 3}},
+		{"1y6M", dt.Span{Years: 1, Months: 6}},
+		{"1q", dt.Span{Months: 3}},
+		{"-1h30m", dt.Span{Duration: -90 * time.Minute}},
+		{"250ms", dt.Span{Duration: 250 * time.Millisecond}},
+		{"1.5h", dt.Span{Duration: 90 * time.Minute}},
+	}
+	for _, c := range cases {
+		got, err := dt.ParseSpan(c.in)
+		if err != nil || got != c.want {
+			t.Errorf("ParseSpan(%q) = %+v, %v", c.in, got, err)
+		}
+	}
+	for _, in := range []string{"", "-", "d", "5", "3x", "1d-2h"} {
+		if _, err := dt.ParseSpan(in); err == nil {
+			t.Errorf("ParseSpan(%q) succeeded", in)
+		}
+	}
+	// 超出 time.Duration 或 int 范围时报错，而不是静默溢出
+	for _, in := range []string{"9999999999h", "2562047h2562047h", "9223372036854775807ms1ms", "9223372036854775807w"} {
+		if _, err := dt.ParseSpan(in); !errors.Is(err, dt.ErrInvalidNumber) {
+			t.Errorf("ParseSpan(%q) error = %v, want ErrInvalidNumber", in, err)
+		}
+	}
+}
+
+func TestSpanMonthEnd(t *testing.T) {
+	jan31 := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	if got := (dt.Span{Months: 1}).AddTo(jan31); !got.Equal(time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Jan 31 + 1mo = %v", got)
+	}
+	may31 := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+	if got := (dt.Span{Months: -3}).AddTo(may31); !got.Equal(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("May 31 - 3mo = %v", got)
+	}
+}
+
+func TestParseRelative(t *testing.T) {
+	sh := mustLoc(t, "Asia/Shanghai")
+	// 2024-03-13 周三 01:30 上海时间，UTC 仍是 3 月 12 日
+	now := time.Date(2024, 3, 12, 17, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"now", time.Date(2024, 3, 13, 1, 30, 0, 0, sh)},
+		{"now-7d/d", time.Date(2024, 3, 6, 0, 0, 0, 0, sh)},
+		{"now/w", time.Date(2024, 3, 11, 0, 0, 0, 0, sh)},
+		{"now-1mo/mo+1d", time.Date(2024, 2, 2, 0, 0, 0, 0, sh)},
+		{"today-1d", time.Date(2024, 3, 12, 0, 0, 0, 0, sh)},
+		{"now/y", time.Date(2024, 1, 1, 0, 0, 0, 0, sh)},
+		{"2024-03-31-1mo", time.Date(2024, 2, 29, 0, 0, 0, 0, sh)},
+		{"2024-05-20 12:00:00/mo", time.Date(2024, 5, 1, 0, 0, 0, 0, sh)},
+		{"2024-05-20T12:00:00Z/d", time.Date(2024, 5, 20, 0, 0, 0, 0, sh)},
+	}
+	for _, c := range cases {
+		got, err := dt.ParseRelative(c.expr, now, sh)
+		if err != nil || !got.Equal(c.want) {
+			t.Errorf("ParseRelative(%q) = %v, %v, want %v", c.expr, got, err, c.want)
+		}
+	}
+	for _, expr := range []string{"", "yesterday", "now*2", "now/x", "now-7x", "now-9999999999h"} {
+		if _, err := dt.ParseRelative(expr, now, sh); err == nil {
+			t.Errorf("ParseRelative(%q) succeeded", expr)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	ny := mustLoc(t, "America/New_York")
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, ny) // 周三
+
+	cases := []struct {
+		expr     string
+		from, to time.Time
+	}{
+		{"today", time.Date(2024, 3, 13, 0, 0, 0, 0, ny), time.Date(2024, 3, 14, 0, 0, 0, 0, ny)},
+		{"yesterday", time.Date(2024, 3, 12, 0, 0, 0, 0, ny), time.Date(2024, 3, 13, 0, 0, 0, 0, ny)},
+		{"this_week", time.Date(2024, 3, 11, 0, 0, 0, 0, ny), time.Date(2024, 3, 18, 0, 0, 0, 0, ny)},
+		// 跨夏令时切换（3 月 10 日），仍按自然日划分
+		{"last_week", time.Date(2024, 3, 4, 0, 0, 0, 0, ny), time.Date(2024, 3, 11, 0, 0, 0, 0, ny)},
+		{"last_month", time.Date(2024, 2, 1, 0, 0, 0, 0, ny), time.Date(2024, 3, 1, 0, 0, 0, 0, ny)},
+		{"this_quarter", time.Date(2024, 1, 1, 0, 0, 0, 0, ny), time.Date(2024, 4, 1, 0, 0, 0, 0, ny)},
+		{"last_year", time.Date(2023, 1, 1, 0, 0, 0, 0, ny), time.Date(2024, 1, 1, 0, 0, 0, 0, ny)},
+		{"last_7d", time.Date(2024, 3, 6, 15, 0, 0, 0, ny), now},
+		{"now-7d/d..now/d", time.Date(2024, 3, 6, 0, 0, 0, 0, ny), time.Date(2024, 3, 13, 0, 0, 0, 0, ny)},
+	}
+	for _, c := range cases {
+		from, to, err := dt.ParseRange(c.expr, now, nil)
+		if err != nil || !from.Equal(c.from) || !to.Equal(c.to) {
+			t.Errorf("ParseRange(%q) = [%v, %v), %v", c.expr, from, to, err)
+		}
+	}
+
+	// 同一时刻在不同时区的 "今天" 不同
+	from, _, _ := dt.ParseRange("today", now, time.UTC)
+	if !from.Equal(time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)) || from.Location() != time.UTC {
+		t.Fatalf("today in UTC = %v", from)
+	}
+
+	for _, expr := range []string{"", "next_week", "this_fortnight", "now..now-1d", "last_"} {
+		if _, _, err := dt.ParseRange(expr, now, nil); err == nil {
+			t.Errorf("ParseRange(%q) succeeded", expr)
+		}
+	}
+	if _, _, err := dt.ParseRange("this_decade", now, nil); !errors.Is(err, dt.ErrInvalidTimeRange) {
+		t.Errorf("this_decade error = %v", err)
+	}
+}
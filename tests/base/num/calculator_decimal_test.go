@@ -0,0 +1,112 @@
+package num_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/base/num"
+)
+
+func TestEvaluateDecimal(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"0.1 + 0.2", "0.3"},
+		{"10 ÷ 4", "2.5"},
+		{"1 / 3", "0.3333333333333333"},
+		{"7 % 3", "1"},
+		{"-(2 + 3) * 2", "-10"},
+		{"min(3, 1.5, 2)", "1.5"},
+		{"max(3, 1.5, 2)", "3"},
+		{"abs(-2.5)", "2.5"},
+		{"round(2.345, 2)", "2.35"},
+		{"round(-2.5)", "-3"},
+		{"floor(2.349, 2)", "2.34"},
+		{"ceil(2.341, 2)", "2.35"},
+		{"floor(-1.5)", "-2"},
+	}
+	for _, c := range cases {
+		got, err := num.EvaluateDecimal(c.expr, nil)
+		if err != nil || got.String() != c.want {
+			t.Errorf("%s = %s, %v, want %s", c.expr, got, err, c.want)
+		}
+	}
+}
+
+func TestCompiledExpression(t *testing.T) {
+	e, err := num.Compile("price*qty*(1-discount)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars := e.Vars(); len(vars) != 3 || vars[0] != "discount" || vars[2] != "qty" {
+		t.Fatalf("Vars = %v", vars)
+	}
+	got, err := e.EvalDecimal(map[string]any{
+		"price":    decimal.RequireFromString("19.99"),
+		"qty":      3,
+		"discount": "0.15",
+	})
+	if err != nil || got.String() != "50.9745" {
+		t.Fatalf("Eval = %s, %v", got, err)
+	}
+	// 同一个编译结果以不同变量复用
+	got, _ = e.EvalDecimal(map[string]any{"price": 0.1, "qty": int64(3), "discount": 0})
+	if got.String() != "0.3" {
+		t.Fatalf("Eval reuse = %s", got)
+	}
+}
+
+func TestEvaluateBool(t *testing.T) {
+	calc := num.NewCalculator()
+	rule := "amount >= 1000 && (level > 2 || vip) && !blocked"
+	vars := map[string]any{"amount": "1000.00", "level": 1, "vip": true, "blocked": false}
+	if ok, err := calc.EvaluateBool(rule, vars); err != nil || !ok {
+		t.Fatalf("rule = %v, %v", ok, err)
+	}
+	vars["blocked"] = true
+	if ok, _ := calc.EvaluateBool(rule, vars); ok {
+		t.Fatal("blocked rule matched")
+	}
+	// 短路：左侧为 false 时不要求右侧的变量
+	if ok, err := calc.EvaluateBool("false && missing > 1", nil); err != nil || ok {
+		t.Fatalf("short circuit = %v, %v", ok, err)
+	}
+	if ok, _ := calc.EvaluateBool("0.1 + 0.2 == 0.3", nil); !ok {
+		t.Fatal("0.1 + 0.2 != 0.3")
+	}
+}
+
+func TestDecimalExpressionErrors(t *testing.T) {
+	if _, err := num.EvaluateDecimal("price * 2", nil); !errors.Is(err, num.ErrUndefinedVariable) {
+		t.Fatalf("undefined variable: %v", err)
+	}
+	for _, expr := range []string{"1 +", "foo(1)", "round(1, 2, 3)", "min()", "1 << 2", `"a"`, "a.b", "1e99999999+1", "1e-99999999"} {
+		if _, err := num.Compile(expr); err == nil {
+			t.Errorf("Compile(%q) succeeded", expr)
+		}
+	}
+	for _, expr := range []string{"1 / 0", "1 % 0", "1 + true", "!1", "1 && true", "true < false", "1 == true",
+		"round(1.5, 99999999)", "floor(1.5, -1)", "ceil(1.5, 1.5)"} {
+		if _, err := num.NewCalculator().Compile(expr); err != nil {
+			t.Fatalf("Compile(%q): %v", expr, err)
+		}
+		if _, err := num.EvaluateDecimal(expr, nil); err == nil {
+			t.Errorf("Eval(%q) succeeded", expr)
+		}
+	}
+	if _, err := num.EvaluateDecimal("1 < 2", nil); err == nil {
+		t.Error("bool result accepted as decimal")
+	}
+	if _, err := num.EvaluateDecimal("x + 1", map[string]any{"x": "1e99999999"}); err == nil {
+		t.Error("string variable with huge exponent accepted")
+	}
+	if got, err := num.EvaluateDecimal("round(1.5, 32) + 1e64 - 1e64", nil); err != nil || got.String() != "1.5" {
+		t.Fatalf("limits = %s, %v", got, err)
+	}
+	if _, err := num.EvaluateDecimal("x", map[string]any{"x": []int{1}}); err == nil {
+		t.Error("unsupported variable type accepted")
+	}
+}
@@ -0,0 +1,167 @@
+package num_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/base/num"
+	"github.com/bizvip/go-utils/consts/currencycode"
+)
+
+func TestMoneyRounding(t *testing.T) {
+	m, err := num.NewMoney(decimal.RequireFromString("10.125"), "cny")
+	if err != nil || m.String() != "10.12 CNY" || m.Currency() != currencycode.CNY {
+		t.Fatalf("NewMoney = %s, %v", m, err)
+	}
+	up, _ := num.MoneyFromDecimal(decimal.RequireFromString("10.125"), currencycode.CNY, num.RoundHalfUp)
+	if up.String() != "10.13 CNY" {
+		t.Fatalf("RoundHalfUp = %s", up)
+	}
+	jpy, _ := num.NewMoney(decimal.RequireFromString("1234.5"), currencycode.JPY)
+	if jpy.String() != "1234 JPY" || jpy.Minor().Int64() != 1234 {
+		t.Fatalf("JPY = %s", jpy)
+	}
+	if _, err := num.ParseMoney("1.005", currencycode.USD); !errors.Is(err, num.ErrAmountPrecision) {
+		t.Fatalf("ParseMoney precision: %v", err)
+	}
+	if _, err := num.ParseMoney("1", "XYZ"); !errors.Is(err, num.ErrUnknownCurrency) {
+		t.Fatalf("ParseMoney currency: %v", err)
+	}
+	if m, _ := num.MoneyFromMinor(12345, currencycode.CNY); m.Amount().String() != "123.45" {
+		t.Fatalf("MoneyFromMinor = %s", m)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, _ := num.ParseMoney("100.10", currencycode.USD)
+	b, _ := num.ParseMoney("0.90", currencycode.USD)
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "101.00 USD" {
+		t.Fatalf("Add = %s, %v", sum, err)
+	}
+	diff, _ := b.Sub(a)
+	if diff.Sign() != -1 || diff.String() != "-99.20 USD" {
+		t.Fatalf("Sub = %s", diff)
+	}
+	tax, _ := a.Mul(decimal.RequireFromString("0.07"), num.RoundHalfUp)
+	if tax.String() != "7.01 USD" {
+		t.Fatalf("Mul = %s", tax)
+	}
+
+	eur, _ := num.ParseMoney("1", currencycode.EUR)
+	if _, err := a.Add(eur); !errors.Is(err, num.ErrCurrencyMismatch) {
+		t.Fatalf("Add across currencies: %v", err)
+	}
+	if _, err := a.Cmp(eur); !errors.Is(err, num.ErrCurrencyMismatch) {
+		t.Fatalf("Cmp across currencies: %v", err)
+	}
+	if a.Equal(eur) {
+		t.Fatal("Equal across currencies")
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	hundred, _ := num.ParseMoney("100", currencycode.CNY)
+	parts, err := hundred.Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"33.34 CNY", "33.33 CNY", "33.33 CNY"}
+	for i, p := range parts {
+		if p.String() != want[i] {
+			t.Fatalf("Split = %v", parts)
+		}
+	}
+
+	neg, _ := num.ParseMoney("-0.05", currencycode.CNY)
+	parts, _ = neg.Allocate(1, 0, 1)
+	if parts[0].String() != "-0.03 CNY" || !parts[1].IsZero() || parts[2].String() != "-0.02 CNY" {
+		t.Fatalf("Allocate negative = %v", parts)
+	}
+
+	yen, _ := num.ParseMoney("1000", currencycode.JPY)
+	parts, _ = yen.Allocate(70, 20, 10)
+	total := num.ZeroMoney(currencycode.JPY)
+	for _, p := range parts {
+		total, _ = total.Add(p)
+	}
+	if !total.Equal(yen) || parts[0].String() != "700 JPY" {
+		t.Fatalf("Allocate = %v", parts)
+	}
+
+	if _, err := hundred.Allocate(0, 0); !errors.Is(err, num.ErrAllocation) {
+		t.Fatalf("Allocate zero ratios: %v", err)
+	}
+	if _, err := hundred.Allocate(1, -1); !errors.Is(err, num.ErrAllocation) {
+		t.Fatalf("Allocate negative ratio: %v", err)
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	cny, _ := num.ParseMoney("1234.5", currencycode.CNY)
+	eur, _ := num.ParseMoney("1234.5", currencycode.EUR)
+	usd, _ := num.NewMoney(decimal.RequireFromString("-1234567.891"), currencycode.USD)
+	jpy, _ := num.ParseMoney("1234567", currencycode.JPY)
+	small, _ := num.ParseMoney("5", currencycode.USD)
+
+	cases := []struct {
+		m      num.Money
+		locale string
+		want   string
+	}{
+		{cny, "zh-CN", "¥1,234.50"},
+		{eur, "de-DE", "1.234,50 €"},
+		{eur, "fr_FR", "1\u202f234,50 €"},
+		{usd, "en-US", "-$1,234,567.89"},
+		{jpy, "ja", "¥1,234,567"},
+		{small, "xx", "$5.00"},
+	}
+	for _, c := range cases {
+		if got := c.m.Format(c.locale); got != c.want {
+			t.Errorf("Format(%s, %s) = %q, want %q", c.m, c.locale, got, c.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	m, _ := num.ParseMoney("1234.5", currencycode.CNY)
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != `{"amount":"1234.50","currency":"CNY"}` {
+		t.Fatalf("Marshal = %s, %v", b, err)
+	}
+	var back num.Money
+	if err := json.Unmarshal(b, &back); err != nil || !back.Equal(m) {
+		t.Fatalf("Unmarshal = %s, %v", back, err)
+	}
+
+	// 零值：null 往返，String 与 Format 一致
+	var zero num.Money
+	if zero.String() != "0" || zero.Format("en") != "0" {
+		t.Fatalf("zero String = %q, Format = %q", zero.String(), zero.Format("en"))
+	}
+	type order struct {
+		Fee num.Money `json:"fee"`
+	}
+	b, err = json.Marshal(order{})
+	if err != nil || string(b) != `{"fee":null}` {
+		t.Fatalf("Marshal zero = %s, %v", b, err)
+	}
+	var o order
+	if err := json.Unmarshal(b, &o); err != nil || o.Fee != (num.Money{}) {
+		t.Fatalf("Unmarshal zero = %+v, %v", o, err)
+	}
+	if err := json.Unmarshal([]byte(`{"fee":{"amount":"0","currency":""}}`), &o); err != nil || o.Fee != (num.Money{}) {
+		t.Fatalf("Unmarshal empty currency = %+v, %v", o, err)
+	}
+	if err := json.Unmarshal([]byte(`{"fee":{"amount":"1","currency":""}}`), &o); !errors.Is(err, num.ErrUnknownCurrency) {
+		t.Fatalf("Unmarshal amount without currency: %v", err)
+	}
+
+	s := num.ZeroMoney(currencycode.CNY)
+	if err := s.Scan([]byte("99.90")); err != nil || s.String() != "99.90 CNY" {
+		t.Fatalf("Scan = %s, %v", s, err)
+	}
+}
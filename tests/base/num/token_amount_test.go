@@ -0,0 +1,92 @@
+package num_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/base/num"
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+)
+
+func TestTokenAmountArithmetic(t *testing.T) {
+	a, err := num.ParseTokenAmount("12.5", 6, cryptocurrency.USDT)
+	if err != nil || a.Raw().String() != "12500000" {
+		t.Fatalf("ParseTokenAmount = %v, %v", a.Raw(), err)
+	}
+	b := num.NewTokenAmountFromInt64(500000, 6, cryptocurrency.USDT)
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "13 USDT" {
+		t.Fatalf("Add = %s, %v", sum, err)
+	}
+	if _, err := b.Sub(a); !errors.Is(err, num.ErrAmountNegative) {
+		t.Fatalf("Sub underflow: %v", err)
+	}
+	if c, err := a.Cmp(b); err != nil || c != 1 {
+		t.Fatalf("Cmp = %d, %v", c, err)
+	}
+
+	eth := num.NewTokenAmountFromInt64(1, 18, cryptocurrency.ETH)
+	if _, err := a.Add(eth); !errors.Is(err, num.ErrTokenMismatch) {
+		t.Fatalf("Add across tokens: %v", err)
+	}
+	max, _ := num.NewTokenAmount(num.MaxUint256, 18, cryptocurrency.ETH)
+	if _, err := max.Add(eth); !errors.Is(err, num.ErrAmountOverflow) {
+		t.Fatalf("Add overflow: %v", err)
+	}
+	if _, err := num.NewTokenAmount(big.NewInt(-1), 18, cryptocurrency.ETH); !errors.Is(err, num.ErrAmountNegative) {
+		t.Fatalf("negative raw: %v", err)
+	}
+}
+
+func TestTokenAmountRounding(t *testing.T) {
+	d := decimal.RequireFromString("1.0000005")
+	if _, err := num.TokenAmountFromDecimal(d, 6, cryptocurrency.USDT, num.RoundExact); !errors.Is(err, num.ErrAmountPrecision) {
+		t.Fatalf("RoundExact: %v", err)
+	}
+	cases := map[num.RoundingMode]string{
+		num.RoundDown:     "1000000",
+		num.RoundUp:       "1000001",
+		num.RoundHalfUp:   "1000001",
+		num.RoundHalfEven: "1000000",
+	}
+	for mode, want := range cases {
+		a, err := num.TokenAmountFromDecimal(d, 6, cryptocurrency.USDT, mode)
+		if err != nil || a.Raw().String() != want {
+			t.Errorf("mode %d: raw = %v, %v; want %s", mode, a.Raw(), err, want)
+		}
+	}
+
+	a, _ := num.ParseTokenAmount("0.123456789012345678", 18, cryptocurrency.ETH)
+	if got := a.Round(4, num.RoundDown).String(); got != "0.1234" {
+		t.Fatalf("Round = %s", got)
+	}
+}
+
+func TestTokenAmountJSONAndSQL(t *testing.T) {
+	a, _ := num.ParseTokenAmount("12.5", 6, cryptocurrency.USDT)
+	data, err := json.Marshal(a)
+	if err != nil || string(data) != `{"raw":"12500000","amount":"12.5","decimals":6,"symbol":"USDT"}` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	var back num.TokenAmount
+	if err := json.Unmarshal(data, &back); err != nil || !back.Equal(a) {
+		t.Fatalf("Unmarshal = %s, %v", back, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"0.1","decimals":18,"symbol":"ETH"}`), &back); err != nil || back.Raw().String() != "100000000000000000" {
+		t.Fatalf("Unmarshal amount = %v, %v", back.Raw(), err)
+	}
+
+	v, _ := a.Value()
+	scanned := num.ZeroTokenAmount(6, cryptocurrency.USDT)
+	if err := scanned.Scan([]byte(v.(string))); err != nil || !scanned.Equal(a) {
+		t.Fatalf("Scan = %s, %v", scanned, err)
+	}
+	if err := scanned.Scan(1.5); !errors.Is(err, num.ErrAmountScanSource) {
+		t.Fatalf("Scan float: %v", err)
+	}
+}
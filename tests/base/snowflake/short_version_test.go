@@ -1,7 +1,9 @@
 package snowflake_test
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bizvip/go-utils/base/snowflake"
 )
@@ -26,3 +28,105 @@ func TestShortIdGeneratorWorkerIDRange(t *testing.T) {
 		t.Fatalf("expected error for workerId out of range (max 31)")
 	}
 }
+
+func TestFastShortIdGeneratorUniqueAndOrdered(t *testing.T) {
+	for _, drift := range []time.Duration{0, 50 * time.Millisecond} {
+		gen, err := snowflake.NewFastShortIdGenerator(3, drift)
+		if err != nil {
+			t.Fatalf("NewFastShortIdGenerator: %v", err)
+		}
+
+		const (
+			workers = 8
+			perWk   = 500
+		)
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			seen = make(map[uint64]struct{}, workers*perWk)
+		)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				local := make([]uint64, 0, perWk)
+				for i := 0; i < perWk; i++ {
+					id, _ := gen.NextID()
+					if n := len(local); n > 0 && id <= local[n-1] {
+						t.Errorf("drift %v: id %d not greater than previous %d", drift, id, local[n-1])
+						return
+					}
+					local = append(local, id)
+				}
+				batch, _ := gen.BatchNext(100)
+				for i := 1; i < len(batch); i++ {
+					if batch[i] <= batch[i-1] {
+						t.Errorf("drift %v: batch not increasing: %d then %d", drift, batch[i-1], batch[i])
+						return
+					}
+				}
+				local = append(local, batch...)
+
+				mu.Lock()
+				defer mu.Unlock()
+				for _, id := range local {
+					if _, dup := seen[id]; dup {
+						t.Errorf("drift %v: duplicate id %d", drift, id)
+						return
+					}
+					seen[id] = struct{}{}
+					if _, worker, _ := gen.Decompose(id); worker != 3 {
+						t.Errorf("drift %v: worker %d, want 3", drift, worker)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	if _, err := snowflake.NewFastShortIdGenerator(0, -time.Second); err == nil {
+		t.Fatalf("expected error for negative drift")
+	}
+}
+
+func TestFastShortIdGeneratorRespectsDrift(t *testing.T) {
+	const drift = 20 * time.Millisecond
+	gen, _ := snowflake.NewFastShortIdGenerator(0, drift)
+	ids, _ := gen.BatchNext(2000) // ~125ms worth of sequence space
+	for _, id := range ids {
+		ts := snowflake.SnowflakeTime(id)
+		if lead := time.Until(ts); lead > drift+5*time.Millisecond {
+			t.Fatalf("id timestamp leads wall clock by %v (max drift %v)", lead, drift)
+		}
+	}
+}
+
+func BenchmarkShortIdGeneratorMutex(b *testing.B) {
+	gen, _ := snowflake.NewShortIdGenerator(1)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = gen.NextID()
+		}
+	})
+}
+
+// BenchmarkShortIdGeneratorFastBurst measures the CAS path alone: the drift window
+// is large enough that the sequence ceiling is never hit during the run.
+func BenchmarkShortIdGeneratorFastBurst(b *testing.B) {
+	gen, _ := snowflake.NewFastShortIdGenerator(1, 24*time.Hour)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = gen.NextID()
+		}
+	})
+}
+
+func BenchmarkShortIdGeneratorFastBurstBatch(b *testing.B) {
+	gen, _ := snowflake.NewFastShortIdGenerator(1, 24*time.Hour)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = gen.BatchNext(16)
+		}
+	})
+}
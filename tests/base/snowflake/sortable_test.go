@@ -0,0 +1,125 @@
+package snowflake_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bizvip/go-utils/base/snowflake"
+)
+
+func TestULIDStringRoundTrip(t *testing.T) {
+	// Spec example timestamp 1469918176385 -> "01ARYZ6S41"
+	g := snowflake.NewULIDGenerator(false)
+	u, err := g.NewAt(time.UnixMilli(1469918176385))
+	if err != nil {
+		t.Fatalf("NewAt: %v", err)
+	}
+	s := u.String()
+	if len(s) != 26 || s[:10] != "01ARYZ6S41" {
+		t.Fatalf("unexpected ULID %q", s)
+	}
+	parsed, err := snowflake.ParseULID(s)
+	if err != nil || parsed != u {
+		t.Fatalf("ParseULID(%q) = %v, %v", s, parsed, err)
+	}
+	if u.Time().UnixMilli() != 1469918176385 {
+		t.Fatalf("Time() = %v", u.Time())
+	}
+	if _, err := snowflake.ParseULID("8ZZZZZZZZZZZZZZZZZZZZZZZZZ"); !errors.Is(err, snowflake.ErrInvalidULID) {
+		t.Fatalf("expected overflow to be rejected, got %v", err)
+	}
+}
+
+func TestMonotonicGeneratorsAreStrictlyIncreasing(t *testing.T) {
+	at := time.UnixMilli(1760000000000)
+	ug := snowflake.NewULIDGenerator(true)
+	vg := snowflake.NewUUIDv7Generator(true)
+
+	var prevU snowflake.ULID
+	var prevV uuid.UUID
+	for i := 0; i < 1000; i++ {
+		u, err := ug.NewAt(at)
+		if err != nil {
+			t.Fatalf("ULID NewAt: %v", err)
+		}
+		if i > 0 && prevU.Compare(u) >= 0 {
+			t.Fatalf("ULID not increasing: %s then %s", prevU, u)
+		}
+		prevU = u
+
+		v, err := vg.NewAt(at)
+		if err != nil {
+			t.Fatalf("UUIDv7 NewAt: %v", err)
+		}
+		if v.Version() != 7 || v.Variant() != uuid.RFC4122 {
+			t.Fatalf("bad version/variant: %s", v)
+		}
+		if i > 0 && prevV.String() >= v.String() {
+			t.Fatalf("UUIDv7 not increasing: %s then %s", prevV, v)
+		}
+		prevV = v
+	}
+	if ms, _ := snowflake.UUIDv7UnixMilli(prevV); ms != uint64(at.UnixMilli()) {
+		t.Fatalf("UUIDv7 timestamp drifted to %d", ms)
+	}
+}
+
+func TestSnowflakeConversions(t *testing.T) {
+	gen, err := snowflake.NewShortIdGenerator(7)
+	if err != nil {
+		t.Fatalf("NewShortIdGenerator: %v", err)
+	}
+	ids, _ := gen.BatchNext(3)
+
+	var prev uuid.UUID
+	for i, id := range ids {
+		v := snowflake.UUIDv7FromSnowflake(id)
+		if v.Version() != 7 {
+			t.Fatalf("not a v7 UUID: %s", v)
+		}
+		back, err := snowflake.SnowflakeFromUUIDv7(v)
+		if err != nil || back != id {
+			t.Fatalf("SnowflakeFromUUIDv7 = %d, %v; want %d", back, err, id)
+		}
+		if i > 0 && prev.String() >= v.String() {
+			t.Fatalf("snowflake order not preserved")
+		}
+		prev = v
+
+		u := snowflake.ULIDFromSnowflake(id)
+		if back, err := snowflake.SnowflakeFromULID(u); err != nil || back != id {
+			t.Fatalf("SnowflakeFromULID = %d, %v; want %d", back, err, id)
+		}
+		if !u.Time().Equal(snowflake.SnowflakeTime(id)) {
+			t.Fatalf("ULID time %v != snowflake time %v", u.Time(), snowflake.SnowflakeTime(id))
+		}
+	}
+
+	random, _ := snowflake.NewUUIDv7()
+	if _, err := snowflake.SnowflakeFromUUIDv7(random); !errors.Is(err, snowflake.ErrNotFromSnowflake) {
+		t.Fatalf("expected ErrNotFromSnowflake, got %v", err)
+	}
+	if _, err := snowflake.UUIDv7Time(uuid.New()); !errors.Is(err, snowflake.ErrNotUUIDv7) {
+		t.Fatalf("expected ErrNotUUIDv7 for v4, got %v", err)
+	}
+}
+
+func TestUUIDULIDConversion(t *testing.T) {
+	v, _ := snowflake.NewUUIDv7()
+	u := snowflake.ULIDFromUUID(v)
+	if snowflake.UUIDFromULID(u) != v || snowflake.UUIDv7FromULID(u) != v {
+		t.Fatalf("UUIDv7 -> ULID -> UUID should be lossless")
+	}
+	vt, _ := snowflake.UUIDv7Time(v)
+	if !u.Time().Equal(vt) {
+		t.Fatalf("timestamps differ: %v vs %v", u.Time(), vt)
+	}
+
+	ul, _ := snowflake.NewULID()
+	if got := snowflake.UUIDv7FromULID(ul); got.Version() != 7 || got.Variant() != uuid.RFC4122 {
+		t.Fatalf("UUIDv7FromULID produced %s", got)
+	}
+}
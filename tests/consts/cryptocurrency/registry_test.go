@@ -0,0 +1,60 @@
+package cryptocurrency_test
+
+import (
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+func TestLookupAsset(t *testing.T) {
+	usdt, ok := cryptocurrency.LookupAsset("usdt")
+	if !ok || usdt.Name("zh") != "泰达币" || usdt.Networks()[0] != cryptocurrency.TronNetwork {
+		t.Fatalf("USDT = %+v", usdt)
+	}
+	if d, ok := usdt.On(cryptocurrency.BscNetwork); !ok || d.Decimals != 18 || d.Standard != cryptocurrency.BEP20 {
+		t.Fatalf("USDT on BSC = %+v", d)
+	}
+	if n, ok := cryptocurrency.Decimals(cryptocurrency.USDT, cryptocurrency.TronNetwork); !ok || n != 6 {
+		t.Fatalf("USDT TRC20 decimals = %d", n)
+	}
+	if cryptocurrency.IsSupported(cryptocurrency.BTC, cryptocurrency.EthNetwork) || !cryptocurrency.IsSupported("eth", cryptocurrency.ArbNetwork) {
+		t.Fatal("IsSupported")
+	}
+
+	a, d, ok := cryptocurrency.LookupContract(cryptocurrency.EthNetwork, "0xdac17f958d2ee523a2206206994597c13d831ec7")
+	if !ok || a.Symbol != cryptocurrency.USDT || d.Decimals != 6 {
+		t.Fatalf("LookupContract = %+v %+v", a, d)
+	}
+	if _, _, ok := cryptocurrency.LookupContract(cryptocurrency.BscNetwork, "0xdac17f958d2ee523a2206206994597c13d831ec7"); ok {
+		t.Fatal("contract matched on the wrong network")
+	}
+
+	// 返回值是副本，修改不影响注册表
+	usdt.Deployments[0].Decimals = 0
+	if n, _ := cryptocurrency.Decimals(cryptocurrency.USDT, cryptocurrency.TronNetwork); n != 6 {
+		t.Fatal("registry mutated through returned asset")
+	}
+}
+
+// 合约地址须能通过对应网络的地址校验，避免录入错误。
+func TestContractAddressesValid(t *testing.T) {
+	for _, a := range cryptocurrency.Assets() {
+		for _, d := range a.Deployments {
+			if d.Native() {
+				continue
+			}
+			if _, known := cryptocoin.GetNetwork(d.Network); !known {
+				continue
+			}
+			normalized, err := cryptocoin.ValidateAddress(d.Network, d.Contract)
+			if err != nil {
+				t.Errorf("%s on %s: %v", a.Symbol, d.Network, err)
+				continue
+			}
+			if normalized != d.Contract {
+				t.Errorf("%s on %s: contract %s, normalized %s", a.Symbol, d.Network, d.Contract, normalized)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package currencycode_test
+
+import (
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/currencycode"
+)
+
+func TestLookup(t *testing.T) {
+	c, ok := currencycode.Lookup(" cny ")
+	if !ok || c.Numeric != 156 || c.MinorUnits != 2 || c.Symbol != "¥" || c.Name("zh-CN") != "人民币" || c.Name("en") != "Chinese Yuan" {
+		t.Fatalf("CNY = %+v", c)
+	}
+	if c, ok := currencycode.LookupNumeric(392); !ok || c.Code != currencycode.JPY || c.MinorUnits != 0 {
+		t.Fatalf("392 = %+v", c)
+	}
+	if currencycode.IsValid("XXX") || !currencycode.IsValid("usd") {
+		t.Fatal("IsValid")
+	}
+	if currencycode.MinorUnits(currencycode.KRW) != 0 || currencycode.MinorUnits("XXX") != 2 {
+		t.Fatal("MinorUnits")
+	}
+}
+
+func TestRegistryConsistent(t *testing.T) {
+	all := currencycode.All()
+	seen := map[int]string{}
+	for i, c := range all {
+		if i > 0 && all[i-1].Code >= c.Code {
+			t.Fatalf("All not sorted at %s", c.Code)
+		}
+		if prev, dup := seen[c.Numeric]; dup {
+			t.Fatalf("numeric %d shared by %s and %s", c.Numeric, prev, c.Code)
+		}
+		seen[c.Numeric] = c.Code
+		if len(c.Code) != 3 || c.Symbol == "" || c.NameZh == "" || c.NameEn == "" {
+			t.Fatalf("incomplete entry %+v", c)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cryptocoin_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+// BIP-0350 / BIP-0173 测试向量
+func TestDecodeBTCAddressValidVectors(t *testing.T) {
+	cases := []struct {
+		addr    string
+		typ     cryptocoin.BTCAddressType
+		network cryptocoin.BTCNetwork
+		script  string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", cryptocoin.BTCP2WPKH, cryptocoin.BTCMainnet,
+			"0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", cryptocoin.BTCP2WSH, cryptocoin.BTCTestnet,
+			"00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", cryptocoin.BTCWitnessUnknown, cryptocoin.BTCMainnet,
+			"5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", cryptocoin.BTCWitnessUnknown, cryptocoin.BTCMainnet, "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", cryptocoin.BTCWitnessUnknown, cryptocoin.BTCMainnet,
+			"5210751e76e8199196d454941c45d1b3a323"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", cryptocoin.BTCP2TR, cryptocoin.BTCTestnet,
+			"5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", cryptocoin.BTCP2TR, cryptocoin.BTCMainnet,
+			"512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", cryptocoin.BTCP2PKH, cryptocoin.BTCMainnet,
+			"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", cryptocoin.BTCP2SH, cryptocoin.BTCMainnet, ""},
+		{"bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080", cryptocoin.BTCP2WPKH, cryptocoin.BTCRegtest,
+			"0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+	}
+	for _, tc := range cases {
+		info, err := cryptocoin.DecodeBTCAddress(tc.addr)
+		if err != nil {
+			t.Errorf("DecodeBTCAddress(%q): %v", tc.addr, err)
+			continue
+		}
+		if info.Type != tc.typ || info.Network != tc.network {
+			t.Errorf("DecodeBTCAddress(%q) = %s/%s, want %s/%s", tc.addr, info.Type, info.Network, tc.typ, tc.network)
+		}
+		if tc.script != "" {
+			if got := hex.EncodeToString(info.ScriptPubKey()); got != tc.script {
+				t.Errorf("ScriptPubKey(%q) = %s, want %s", tc.addr, got, tc.script)
+			}
+		}
+	}
+}
+
+func TestDecodeBTCAddressInvalidVectors(t *testing.T) {
+	cases := []struct {
+		addr   string
+		reason error
+	}{
+		{"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut", cryptocoin.ErrAddressFormat},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", cryptocoin.ErrAddressChecksum},
+		{"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf", cryptocoin.ErrAddressChecksum},
+		{"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL", cryptocoin.ErrAddressChecksum},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", cryptocoin.ErrAddressChecksum},
+		{"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47", cryptocoin.ErrAddressChecksum},
+		{"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4", cryptocoin.ErrAddressFormat},
+		{"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R", cryptocoin.ErrAddressFormat},
+		{"bc1pw5dgrnzv", cryptocoin.ErrAddressFormat},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav", cryptocoin.ErrAddressFormat},
+		{"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P", cryptocoin.ErrAddressFormat},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq", cryptocoin.ErrAddressFormat},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf", cryptocoin.ErrAddressFormat},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j", cryptocoin.ErrAddressFormat},
+		{"bc1gmk9yu", cryptocoin.ErrAddressFormat},
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", cryptocoin.ErrAddressChecksum},
+	}
+	for _, tc := range cases {
+		_, err := cryptocoin.DecodeBTCAddress(tc.addr)
+		if !errors.Is(err, tc.reason) {
+			t.Errorf("DecodeBTCAddress(%q) error = %v, want %v", tc.addr, err, tc.reason)
+		}
+		if cryptocoin.IsValidBTCAddress(tc.addr) {
+			t.Errorf("IsValidBTCAddress(%q) = true", tc.addr)
+		}
+	}
+}
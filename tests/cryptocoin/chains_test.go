@@ -0,0 +1,103 @@
+package cryptocoin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+func TestDetectAddressNewChains(t *testing.T) {
+	cases := []struct {
+		addr string
+		want cryptocoin.AddrType
+	}{
+		{"So11111111111111111111111111111111111111112", cryptocoin.AddrSOL},
+		{"11111111111111111111111111111111", cryptocoin.AddrSOL},
+		{"LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ", cryptocoin.AddrLTC},
+		{"MJaRnao1s62a2zAKSkmG582KbLKianqb7v", cryptocoin.AddrLTC},
+		{"ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", cryptocoin.AddrLTC},
+		{"DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE", cryptocoin.AddrDOGE},
+		{"A37YDYSwz3438rFtm1SLVcQHyD7JeueC9H", cryptocoin.AddrDOGE},
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", cryptocoin.AddrXRP},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV18pX8yuPT7y4xaEHi", cryptocoin.AddrXRP},
+		{"cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c", cryptocoin.AddrATOM},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", cryptocoin.AddrBCH},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", cryptocoin.AddrBCH},
+		// 已有链不受影响
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", cryptocoin.AddrBTC},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", cryptocoin.AddrTRC20},
+	}
+	for _, tc := range cases {
+		if got, ok := cryptocoin.DetectAddress(tc.addr); !ok || got != tc.want {
+			t.Errorf("DetectAddress(%q) = %s, %v; want %s", tc.addr, got, ok, tc.want)
+		}
+	}
+}
+
+func TestChainValidatorsReject(t *testing.T) {
+	if cryptocoin.IsValidLTCAddress("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy") {
+		t.Errorf("legacy 3... P2SH must not be accepted as LTC")
+	}
+	if cryptocoin.IsValidATOMAddress("cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k0p8k26") {
+		t.Errorf("bech32m cosmos address must be rejected")
+	}
+	if cryptocoin.IsValidATOMAddress("osmo1w508d6qejxtdg4y5r3zarvary0c5xw7kjxy2e2") ||
+		!cryptocoin.IsValidCosmosAddress("osmo1w508d6qejxtdg4y5r3zarvary0c5xw7kjxy2e2", "osmo") {
+		t.Errorf("cosmos HRP must be matched exactly")
+	}
+	if cryptocoin.IsValidBCHAddress("bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q") {
+		t.Errorf("bad cashaddr checksum accepted")
+	}
+	if cryptocoin.IsValidSolanaAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t") {
+		t.Errorf("25-byte base58 must not be a Solana address")
+	}
+
+	_, err := cryptocoin.ValidateAddress(cryptocurrency.BchNetwork, "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q")
+	if !errors.Is(err, cryptocoin.ErrAddressChecksum) {
+		t.Errorf("expected checksum reason, got %v", err)
+	}
+	got, err := cryptocoin.ValidateAddress(cryptocurrency.BchNetwork, "QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A")
+	if err != nil || got != "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a" {
+		t.Errorf("ValidateAddress(BCH) = %q, %v", got, err)
+	}
+}
+
+func TestXRPAddressTags(t *testing.T) {
+	const classic = "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"
+	tag := uint32(1)
+	x, err := cryptocoin.XRPXAddress(classic, &tag, false)
+	if err != nil || x != "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC" {
+		t.Fatalf("XRPXAddress = %q, %v", x, err)
+	}
+	parsed, err := cryptocoin.ParseXRPAddress(x)
+	if err != nil || parsed.Classic != classic || parsed.Tag == nil || *parsed.Tag != 1 || parsed.Testnet {
+		t.Fatalf("ParseXRPAddress(%q) = %+v, %v", x, parsed, err)
+	}
+
+	zero := uint32(0)
+	if x, _ := cryptocoin.XRPXAddress(classic, &zero, false); x != "XVLhHMPHU98es4dbozjVtdWzVrDjtV8AqEL4xcZj5whKbmc" {
+		t.Fatalf("XRPXAddress tag 0 = %q", x)
+	}
+	noTag, _ := cryptocoin.XRPXAddress(classic, nil, false)
+	if parsed, err := cryptocoin.ParseXRPAddress(noTag); err != nil || parsed.Tag != nil {
+		t.Fatalf("X-address without tag parsed as %+v, %v", parsed, err)
+	}
+	testnet, _ := cryptocoin.XRPXAddress(classic, &tag, true)
+	if cryptocoin.IsValidXRPAddress(testnet) {
+		t.Fatalf("testnet X-address accepted as mainnet")
+	}
+}
+
+func TestBCHLegacyConversion(t *testing.T) {
+	cases := map[string]string{
+		"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu": "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"3CWFddi6m4ndiGyKqzYvsFYagqDLPVMTzC": "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq",
+	}
+	for legacy, want := range cases {
+		if got, err := cryptocoin.BCHFromLegacy(legacy); err != nil || got != want {
+			t.Errorf("BCHFromLegacy(%q) = %q, %v; want %q", legacy, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package cryptocoin_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+func TestTronConversions(t *testing.T) {
+	const (
+		base58 = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		hex41  = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	)
+	h, err := cryptocoin.TronToHex(base58)
+	if err != nil || h != hex41 {
+		t.Fatalf("TronToHex = %q, %v", h, err)
+	}
+	evm, err := cryptocoin.TronToEVM(base58)
+	if err != nil || !cryptocoin.IsValidEVMAddress(evm) {
+		t.Fatalf("TronToEVM = %q, %v", evm, err)
+	}
+	for _, in := range []string{hex41, "0x" + hex41, evm, "0x" + hex41[2:]} {
+		got, err := cryptocoin.TronFromHex(in)
+		if err != nil || got != base58 {
+			t.Fatalf("TronFromHex(%q) = %q, %v", in, got, err)
+		}
+	}
+	if got, err := cryptocoin.EVMToTron(evm); err != nil || got != base58 {
+		t.Fatalf("EVMToTron = %q, %v", got, err)
+	}
+	if _, err := cryptocoin.TronFromHex("42a614f803b6fd780986a42c78ec9c7f77e6ded13c"); err == nil {
+		t.Fatalf("expected non-0x41 prefix to be rejected")
+	}
+}
+
+func TestTONConversions(t *testing.T) {
+	const (
+		raw           = "0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8"
+		bounceable    = "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N"
+		nonBounceable = "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI"
+	)
+	if got, err := cryptocoin.TONRawToFriendly(raw, true, false); err != nil || got != bounceable {
+		t.Fatalf("TONRawToFriendly(bounceable) = %q, %v", got, err)
+	}
+	if got, err := cryptocoin.TONRawToFriendly(raw, false, false); err != nil || got != nonBounceable {
+		t.Fatalf("TONRawToFriendly(non-bounceable) = %q, %v", got, err)
+	}
+	for _, in := range []string{bounceable, nonBounceable, raw} {
+		if got, err := cryptocoin.TONFriendlyToRaw(in); err != nil || got != raw {
+			t.Fatalf("TONFriendlyToRaw(%q) = %q, %v", in, got, err)
+		}
+		if !cryptocoin.IsValidTONAddress(in) {
+			t.Fatalf("IsValidTONAddress(%q) = false", in)
+		}
+	}
+
+	testnet, _ := cryptocoin.TONRawToFriendly(raw, false, true)
+	a, err := cryptocoin.ParseTONAddress(testnet)
+	if err != nil || !a.Testnet || a.Bounceable || a.Raw() != raw {
+		t.Fatalf("ParseTONAddress(%q) = %+v, %v", testnet, a, err)
+	}
+}
+
+// 解析与校验对 friendly 地址的两种 Base64 字母表结论一致
+func TestTONFriendlyAlphabets(t *testing.T) {
+	const raw = "0:fbfffe52e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8"
+	for _, tc := range []struct {
+		addr string
+		ok   bool
+	}{
+		{"EQD7__5S5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqC7O", true},
+		{"EQD7//5S5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqC7O", true},
+		{"EQD7//5S5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqC7P", false},
+		{"EQD7//5S5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqC7O==", false},
+	} {
+		a, err := cryptocoin.ParseTONAddress(tc.addr)
+		if (err == nil) != tc.ok || tc.ok && (a.Raw() != raw || !a.Bounceable) {
+			t.Errorf("ParseTONAddress(%q) = %+v, %v", tc.addr, a, err)
+		}
+		if got := cryptocoin.IsValidTONAddress(tc.addr); got != tc.ok {
+			t.Errorf("IsValidTONAddress(%q) = %v", tc.addr, got)
+		}
+		if _, err := cryptocoin.ValidateAddress(cryptocurrency.TonNetwork, tc.addr); (err == nil) != tc.ok {
+			t.Errorf("ValidateAddress(%q) error = %v", tc.addr, err)
+		}
+	}
+}
+
+func TestEIP55Conversions(t *testing.T) {
+	const checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	if got, err := cryptocoin.ToEIP55Address("0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"); err != nil || got != checksummed {
+		t.Fatalf("ToEIP55Address = %q, %v", got, err)
+	}
+	if got, err := cryptocoin.ToLowerEVMAddress(checksummed); err != nil || got != "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed" {
+		t.Fatalf("ToLowerEVMAddress = %q, %v", got, err)
+	}
+	if _, err := cryptocoin.ToEIP55Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"); err == nil {
+		t.Fatalf("expected bad mixed-case checksum to be rejected")
+	}
+}
+
+func TestBTCEncodeRoundTrip(t *testing.T) {
+	for _, addr := range []string{
+		"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+	} {
+		info, err := cryptocoin.DecodeBTCAddress(addr)
+		if err != nil {
+			t.Fatalf("DecodeBTCAddress(%q): %v", addr, err)
+		}
+		got, err := cryptocoin.EncodeBTCAddress(info.Network, info.Type, info.Hash)
+		if err != nil || got != addr {
+			t.Fatalf("EncodeBTCAddress(%s, %s) = %q, %v; want %q", info.Network, info.Type, got, err, addr)
+		}
+	}
+	for _, c := range []struct {
+		typ  cryptocoin.BTCAddressType
+		size int
+	}{
+		{cryptocoin.BTCP2WPKH, 32},
+		{cryptocoin.BTCP2WSH, 20},
+		{cryptocoin.BTCP2TR, 20},
+		{cryptocoin.BTCP2PKH, 32},
+	} {
+		if _, err := cryptocoin.EncodeBTCAddress(cryptocoin.BTCMainnet, c.typ, bytes.Repeat([]byte{1}, c.size)); !errors.Is(err, cryptocoin.ErrAddressFormat) {
+			t.Fatalf("EncodeBTCAddress(%s, %d bytes) error = %v", c.typ, c.size, err)
+		}
+	}
+	// regtest 的 Base58 地址与 testnet 无法区分
+	regtest, _ := cryptocoin.EncodeBTCAddress(cryptocoin.BTCRegtest, cryptocoin.BTCP2PKH, bytes.Repeat([]byte{1}, 20))
+	if info, err := cryptocoin.DecodeBTCAddress(regtest); err != nil || info.Network != cryptocoin.BTCTestnet {
+		t.Fatalf("regtest P2PKH decoded as %+v, %v", info, err)
+	}
+	if _, err := cryptocoin.EncodeSegWitAddress(cryptocoin.BTCMainnet, 0, bytes.Repeat([]byte{1}, 16)); err == nil {
+		t.Fatalf("expected v0 program of 16 bytes to be rejected")
+	}
+}
@@ -0,0 +1,267 @@
+package cryptocoin_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func mustSeed(t *testing.T, mnemonic string) []byte {
+	t.Helper()
+	seed, err := cryptocoin.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		t.Fatalf("MnemonicToSeed: %v", err)
+	}
+	return seed
+}
+
+func TestMnemonicVectors(t *testing.T) {
+	cases := []struct{ entropy, mnemonic string }{
+		{"00000000000000000000000000000000", testMnemonic},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"80808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{"ffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"},
+	}
+	for _, tc := range cases {
+		e, _ := hex.DecodeString(tc.entropy)
+		got, err := cryptocoin.MnemonicFromEntropy(e)
+		if err != nil || got != tc.mnemonic {
+			t.Fatalf("MnemonicFromEntropy(%s) = %q, %v", tc.entropy, got, err)
+		}
+		back, err := cryptocoin.MnemonicToEntropy(strings.ToUpper(tc.mnemonic))
+		if err != nil || hex.EncodeToString(back) != tc.entropy {
+			t.Fatalf("MnemonicToEntropy(%q) = %x, %v", tc.mnemonic, back, err)
+		}
+	}
+
+	seed := mustSeed(t, testMnemonic)
+	if got := hex.EncodeToString(seed); got != "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4" {
+		t.Fatalf("seed = %s", got)
+	}
+
+	if _, err := cryptocoin.MnemonicToEntropy(strings.Replace(testMnemonic, "about", "above", 1)); !errors.Is(err, cryptocoin.ErrMnemonicChecksum) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+	if _, err := cryptocoin.MnemonicToEntropy(strings.Replace(testMnemonic, "about", "bitcoin", 1)); !errors.Is(err, cryptocoin.ErrMnemonicWord) {
+		t.Fatalf("expected word error, got %v", err)
+	}
+
+	m, err := cryptocoin.NewMnemonic(256)
+	if err != nil || len(strings.Fields(m)) != 24 || !cryptocoin.IsValidMnemonic(m) {
+		t.Fatalf("NewMnemonic(256) = %q, %v", m, err)
+	}
+}
+
+func TestBIP32Vector1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := cryptocoin.NewMasterKey(seed)
+	if err != nil {
+		t.Fatalf("NewMasterKey: %v", err)
+	}
+	cases := []struct{ path, xprv, xpub string }{
+		{"m",
+			"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+		{"m/0H",
+			"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+	}
+	for _, tc := range cases {
+		k, err := master.Derive(tc.path)
+		if err != nil {
+			t.Fatalf("Derive(%s): %v", tc.path, err)
+		}
+		if got := k.String(); got != tc.xprv {
+			t.Fatalf("%s xprv = %s", tc.path, got)
+		}
+		if got := k.Neuter().String(); got != tc.xpub {
+			t.Fatalf("%s xpub = %s", tc.path, got)
+		}
+		parsed, err := cryptocoin.ParseExtendedKey(tc.xprv)
+		if err != nil || parsed.String() != tc.xprv {
+			t.Fatalf("ParseExtendedKey(%s) = %v", tc.path, err)
+		}
+	}
+
+	// m/0H/1：从 m/0H 的 xpub 只读派生必须与私钥派生一致
+	want := "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"
+	priv, _ := master.Derive("m/0'/1")
+	if got := priv.Neuter().String(); got != want {
+		t.Fatalf("m/0H/1 xpub = %s", got)
+	}
+	xpub, err := cryptocoin.ParseExtendedKey(cases[1].xpub)
+	if err != nil {
+		t.Fatalf("ParseExtendedKey(xpub): %v", err)
+	}
+	pub, err := xpub.Derive("1")
+	if err != nil || pub.String() != want {
+		t.Fatalf("watch-only m/0H/1 = %v, %v", pub, err)
+	}
+	if _, err := xpub.Derive("0'"); !errors.Is(err, cryptocoin.ErrHardenedFromPublic) {
+		t.Fatalf("expected ErrHardenedFromPublic, got %v", err)
+	}
+}
+
+func TestBIP44Addresses(t *testing.T) {
+	master, err := cryptocoin.NewMasterKey(mustSeed(t, testMnemonic))
+	if err != nil {
+		t.Fatalf("NewMasterKey: %v", err)
+	}
+	derive := func(purpose, coin uint32) *cryptocoin.ExtendedKey {
+		k, err := master.Derive(cryptocoin.AddressPath(purpose, coin, 0, 0, 0))
+		if err != nil {
+			t.Fatalf("Derive: %v", err)
+		}
+		return k
+	}
+
+	if got := derive(cryptocoin.PurposeBIP44, cryptocoin.CoinTypeETH).EVMAddress(); got != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("ETH = %s", got)
+	}
+	cases := []struct {
+		purpose uint32
+		typ     cryptocoin.BTCAddressType
+		want    string
+	}{
+		{cryptocoin.PurposeBIP44, cryptocoin.BTCP2PKH, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{cryptocoin.PurposeBIP84, cryptocoin.BTCP2WPKH, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{cryptocoin.PurposeBIP86, cryptocoin.BTCP2TR, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	}
+	for _, tc := range cases {
+		got, err := derive(tc.purpose, cryptocoin.CoinTypeBTC).BTCAddress(cryptocoin.BTCMainnet, tc.typ)
+		if err != nil || got != tc.want {
+			t.Errorf("BTC %s = %s, %v", tc.typ, got, err)
+		}
+		if !cryptocoin.IsValidBTCAddress(got) {
+			t.Errorf("%s rejected by IsValidBTCAddress", got)
+		}
+	}
+	testnet, _ := derive(cryptocoin.PurposeBIP49, cryptocoin.CoinTypeTestnet).BTCAddress(cryptocoin.BTCTestnet, cryptocoin.BTCP2SH)
+	if testnet != "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2" {
+		t.Errorf("BIP-49 testnet = %s", testnet)
+	}
+
+	tron := derive(cryptocoin.PurposeBIP44, cryptocoin.CoinTypeTRX)
+	addr := tron.TronAddress()
+	if !cryptocoin.IsValidTRC20Address(addr) {
+		t.Fatalf("%s rejected by IsValidTRC20Address", addr)
+	}
+	if evm, _ := cryptocoin.TronToEVM(addr); evm != tron.EVMAddress() {
+		t.Errorf("TronToEVM(%s) = %s, want %s", addr, evm, tron.EVMAddress())
+	}
+}
+
+func TestWatchOnlyFromZPub(t *testing.T) {
+	master, _ := cryptocoin.NewMasterKey(mustSeed(t, testMnemonic))
+	acct, _ := master.Derive(cryptocoin.AccountPath(cryptocoin.PurposeBIP84, cryptocoin.CoinTypeBTC, 0))
+	zpub := acct.Neuter().WithVersion(cryptocoin.VersionZPub).String()
+	if zpub != "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs" {
+		t.Fatalf("zpub = %s", zpub)
+	}
+
+	watch, err := cryptocoin.ParseExtendedKey(zpub)
+	if err != nil || watch.IsPrivate() {
+		t.Fatalf("ParseExtendedKey(zpub) = %v", err)
+	}
+	v := watch.Version()
+	for i := uint32(0); i < 3; i++ {
+		w, err := watch.Derive("0/" + strconv.Itoa(int(i)))
+		if err != nil {
+			t.Fatalf("watch Derive: %v", err)
+		}
+		p, _ := master.Derive(cryptocoin.AddressPath(cryptocoin.PurposeBIP84, cryptocoin.CoinTypeBTC, 0, 0, i))
+		got, _ := w.BTCAddress(v.Network, v.AddressType)
+		want, _ := p.BTCAddress(cryptocoin.BTCMainnet, cryptocoin.BTCP2WPKH)
+		if got != want {
+			t.Fatalf("index %d: watch-only %s != %s", i, got, want)
+		}
+	}
+}
+
+func TestSLIP10Ed25519AndTONWallet(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	cases := []struct{ path, priv, pub string }{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0H", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+	}
+	for _, tc := range cases {
+		k, err := cryptocoin.DeriveEd25519Key(seed, tc.path)
+		if err != nil {
+			t.Fatalf("DeriveEd25519Key(%s): %v", tc.path, err)
+		}
+		if got := hex.EncodeToString(k.Seed()); got != tc.priv {
+			t.Fatalf("%s priv = %s", tc.path, got)
+		}
+		if got := hex.EncodeToString(k.Public().(ed25519.PublicKey)); got != tc.pub {
+			t.Fatalf("%s pub = %s", tc.path, got)
+		}
+	}
+	if _, err := cryptocoin.DeriveEd25519Key(seed, "m/0"); !errors.Is(err, cryptocoin.ErrHardenedOnly) {
+		t.Fatalf("expected ErrHardenedOnly, got %v", err)
+	}
+
+	// 只含一个空 cell 的 BOC，空 cell 哈希为公开常量
+	empty, _ := hex.DecodeString("b5ee9c72010101010002000000")
+	code, err := cryptocoin.ParseTONWalletCode(empty)
+	if err != nil {
+		t.Fatalf("ParseTONWalletCode: %v", err)
+	}
+	if got := hex.EncodeToString(code.Hash[:]); got != "96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7" || code.Depth != 0 {
+		t.Fatalf("empty cell hash = %s depth %d", got, code.Depth)
+	}
+
+	key, _ := cryptocoin.DeriveEd25519Key(mustSeed(t, testMnemonic), cryptocoin.AccountPath(cryptocoin.PurposeBIP44, cryptocoin.CoinTypeTON, 0))
+	addr, err := cryptocoin.TONWalletV4Address(code, key.Public().(ed25519.PublicKey), cryptocoin.TONWalletDefaultID)
+	if err != nil {
+		t.Fatalf("TONWalletV4Address: %v", err)
+	}
+	if f := addr.Friendly(); !strings.HasPrefix(f, "UQ") || !cryptocoin.IsValidTONAddress(f) {
+		t.Fatalf("friendly = %s", f)
+	}
+}
+
+// 官方 wallet v4r2 合约代码（ton-core WalletContractV4 所用 BOC）。
+const tonWalletV4R2CodeBOC = "te6cckECFAEAAtQAART/APSkE/S88sgLAQIBIAIDAgFIBAUE+PKDCNcYINMf0x/THwL4I7vyZO1E0NMf0x/T//QE0VFDuvKhUVG68qIF+QFUEGT5EPKj+AAkpMjLH1JAyx9SMMv/UhD0AMntVPgPAdMHIcAAn2xRkyDXSpbTB9QC+wDoMOAhwAHjACHAAuMAAcADkTDjDQOkyMsfEssfy/8QERITAubQAdDTAyFxsJJfBOAi10nBIJJfBOAC0x8hghBwbHVnvSKCEGRzdHK9sJJfBeAD+kAwIPpEAcjKB8v/ydDtRNCBAUDXIfQEMFyBAQj0Cm+hMbOSXwfgBdM/yCWCEHBsdWe6kjgw4w0DghBkc3RyupJfBuMNBgcCASAICQB4AfoA9AQw+CdvIjBQCqEhvvLgUIIQcGx1Z4MesXCAGFAEywUmzxZY+gIZ9ADLaRfLH1Jgyz8gyYBA+wAGAIpQBIEBCPRZMO1E0IEBQNcgyAHPFvQAye1UAXKwjiOCEGRzdHKDHrFwgBhQBcsFUAPPFiP6AhPLassfyz/JgED7AJJfA+ICASAKCwBZvSQrb2omhAgKBrkPoCGEcNQICEekk30pkQzmkD6f+YN4EoAbeBAUiYcVnzGEAgFYDA0AEbjJftRNDXCx+AA9sp37UTQgQFA1yH0BDACyMoHy//J0AGBAQj0Cm+hMYAIBIA4PABmtznaiaEAga5Drhf/AABmvHfaiaEAQa5DrhY/AAG7SB/oA1NQi+QAFyMoHFcv/ydB3dIAYyMsFywIizxZQBfoCFMtrEszMyXP7AMhAFIEBCPRR8qcCAHCBAQjXGPoA0z/IVCBHgQEI9FHyp4IQbm90ZXB0gBjIywXLAlAGzxZQBPoCFMtqEssfyz/Jc/sAAgBsgQEI1xj6ANM/MFIkgQEI9Fnyp4IQZHN0cnB0gBjIywXLAlAFzxZQA/oCE8tqyx8Syz/Jc/sAAAr0AMntVGliJeU="
+
+func TestTONWalletV4R2Address(t *testing.T) {
+	boc, err := base64.StdEncoding.DecodeString(tonWalletV4R2CodeBOC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := cryptocoin.ParseTONWalletCode(boc)
+	if err != nil {
+		t.Fatalf("ParseTONWalletCode: %v", err)
+	}
+	if got := hex.EncodeToString(code.Hash[:]); got != cryptocoin.TONWalletV4R2CodeHash || code.Depth != 7 {
+		t.Fatalf("v4r2 code hash = %s depth %d", got, code.Depth)
+	}
+
+	// 公钥取自 SLIP-10 ed25519 测试向量 1 的 m/0H；期望地址由独立实现按 StateInit 哈希规则算出
+	pub, _ := hex.DecodeString("8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c")
+	addr, err := cryptocoin.TONWalletV4Address(code, pub, cryptocoin.TONWalletDefaultID)
+	if err != nil {
+		t.Fatalf("TONWalletV4Address: %v", err)
+	}
+	if got := addr.Raw(); got != "0:82dc3dafb096dd6dbd281630e1daf9d7c9570fd309364d06a86389ea9ef246d6" {
+		t.Fatalf("raw = %s", got)
+	}
+	if got := addr.Friendly(); got != "UQCC3D2vsJbdbb0oFjDh2vnXyVcP0wk2TQaoY4nqnvJG1uzj" {
+		t.Fatalf("friendly = %s", got)
+	}
+}
+
+func TestParseTONWalletCodeRejectsOversizedCount(t *testing.T) {
+	// 23 字节的 BOC 声称有 0x7fffffff 个 cell，必须在分配前拒绝
+	boc, _ := hex.DecodeString("b5ee9c7204017fffffff00000001000000000000000000")
+	if _, err := cryptocoin.ParseTONWalletCode(boc); !errors.Is(err, cryptocoin.ErrInvalidBOC) {
+		t.Fatalf("expected ErrInvalidBOC, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package cryptocoin_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+func TestValidateAddressNormalizes(t *testing.T) {
+	cases := []struct {
+		network string
+		addr    string
+		want    string
+	}{
+		{cryptocurrency.EthNetwork, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{cryptocurrency.BscNetwork, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{cryptocurrency.TronNetwork, " TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t ", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		{cryptocurrency.BtcNetwork, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{cryptocurrency.BtcNetwork, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{cryptocurrency.BtcTestNetwork, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+		{cryptocurrency.TonNetwork, "0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8", "0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8"},
+	}
+	for _, tc := range cases {
+		got, err := cryptocoin.ValidateAddress(tc.network, tc.addr)
+		if err != nil || got != tc.want {
+			t.Errorf("ValidateAddress(%s, %q) = %q, %v; want %q", tc.network, tc.addr, got, err, tc.want)
+		}
+	}
+}
+
+func TestValidateAddressReasons(t *testing.T) {
+	cases := []struct {
+		network string
+		addr    string
+		reason  error
+	}{
+		{cryptocurrency.EthNetwork, "", cryptocoin.ErrAddressEmpty},
+		{cryptocurrency.EthNetwork, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", cryptocoin.ErrAddressChecksum},
+		{cryptocurrency.EthNetwork, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", cryptocoin.ErrAddressFormat},
+		{cryptocurrency.EthNetwork, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", cryptocoin.ErrAddressOtherNetwork},
+		{cryptocurrency.TronNetwork, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6T", cryptocoin.ErrAddressChecksum},
+		{cryptocurrency.BtcNetwork, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", cryptocoin.ErrAddressTestnet},
+		{cryptocurrency.BtcTestNetwork, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", cryptocoin.ErrAddressMainnet},
+		{cryptocurrency.BtcNetwork, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", cryptocoin.ErrAddressChecksum},
+	}
+	for _, tc := range cases {
+		_, err := cryptocoin.ValidateAddress(tc.network, tc.addr)
+		if !errors.Is(err, tc.reason) {
+			t.Errorf("ValidateAddress(%s, %q) error = %v; want %v", tc.network, tc.addr, err, tc.reason)
+		}
+	}
+
+	_, err := cryptocoin.ValidateAddress(cryptocurrency.BscNetwork, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	var ae *cryptocoin.AddressError
+	if !errors.As(err, &ae) || !slices.Equal(ae.Candidates, []string{cryptocurrency.TronNetwork}) {
+		t.Fatalf("expected TRON candidate, got %v", err)
+	}
+	if _, err := cryptocoin.ValidateAddress("NOPE", "x"); !errors.Is(err, cryptocoin.ErrUnknownNetwork) {
+		t.Fatalf("expected ErrUnknownNetwork, got %v", err)
+	}
+}
+
+func TestDetectNetworksListsAllEVMChains(t *testing.T) {
+	var ids []string
+	for _, n := range cryptocoin.DetectNetworks("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed") {
+		ids = append(ids, n.ID)
+	}
+	want := []string{cryptocurrency.ArbNetwork, cryptocurrency.BscNetwork, cryptocurrency.EthNetwork, cryptocurrency.PolygonNetwork}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("DetectNetworks = %v, want %v", ids, want)
+	}
+
+	if err := cryptocoin.RegisterNetwork(cryptocoin.Network{ID: "X", Family: "nope"}); err == nil {
+		t.Fatalf("expected unsupported family to be rejected")
+	}
+}
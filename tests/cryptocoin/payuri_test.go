@@ -0,0 +1,103 @@
+package cryptocoin_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bizvip/go-utils/consts/cryptocurrency"
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+func TestPaymentRequestURI(t *testing.T) {
+	cases := []struct {
+		req  cryptocoin.PaymentRequest
+		want string
+	}{
+		{
+			cryptocoin.PaymentRequest{Network: cryptocurrency.BtcNetwork, Address: "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4",
+				Amount: big.NewInt(150000), Label: "Go Utils", Message: "order #42"},
+			"bitcoin:bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4?amount=0.0015&label=Go%20Utils&message=order%20%2342",
+		},
+		{
+			cryptocoin.PaymentRequest{Network: cryptocurrency.BscNetwork, Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+				Amount: big.NewInt(1e18)},
+			"ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@56?value=1000000000000000000",
+		},
+		{
+			cryptocoin.PaymentRequest{Network: cryptocurrency.EthNetwork, Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+				Token: "0xdac17f958d2ee523a2206206994597c13d831ec7", Amount: big.NewInt(2500000)},
+			"ethereum:0xdAC17F958D2ee523a2206206994597C13D831ec7@1/transfer?address=0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed&uint256=2500000",
+		},
+		{
+			cryptocoin.PaymentRequest{Network: cryptocurrency.TronNetwork, Address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+				Token: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Amount: big.NewInt(1000000)},
+			"tron:TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t?amount=1000000&token=TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		},
+		{
+			cryptocoin.PaymentRequest{Network: cryptocurrency.TonNetwork, Address: "0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8",
+				Amount: big.NewInt(5e8), Message: "uid 1001"},
+			"ton://transfer/0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8?amount=500000000&text=uid%201001",
+		},
+	}
+	for _, tc := range cases {
+		got, err := tc.req.URI()
+		if err != nil || got != tc.want {
+			t.Errorf("URI() = %q, %v; want %q", got, err, tc.want)
+			continue
+		}
+		back, err := cryptocoin.ParsePaymentURI(got)
+		if err != nil {
+			t.Errorf("ParsePaymentURI(%q): %v", got, err)
+			continue
+		}
+		again, _ := back.URI()
+		if again != got {
+			t.Errorf("round trip %q -> %q", got, again)
+		}
+	}
+}
+
+func TestParsePaymentURI(t *testing.T) {
+	// BIP-21 规范示例（地址替换为有效地址）
+	r, err := cryptocoin.ParsePaymentURI("bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=50&label=Luke-Jr&message=Donation%20for%20project%20xyz")
+	if err != nil || r.Network != cryptocurrency.BtcNetwork || r.Amount.Cmp(big.NewInt(50e8)) != 0 ||
+		r.Label != "Luke-Jr" || r.Message != "Donation for project xyz" {
+		t.Fatalf("BIP-21: %+v, %v", r, err)
+	}
+	r, err = cryptocoin.ParsePaymentURI("bitcoin:tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx?amount=0.00000001")
+	if err != nil || r.Network != cryptocurrency.BtcTestNetwork || r.Amount.Int64() != 1 {
+		t.Fatalf("BIP-21 testnet: %+v, %v", r, err)
+	}
+
+	// EIP-681：科学计数法金额、省略链 ID 时为主网
+	r, err = cryptocoin.ParsePaymentURI("ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=2.014e18")
+	if err != nil || r.Network != cryptocurrency.EthNetwork || r.Amount.String() != "2014000000000000000" {
+		t.Fatalf("EIP-681 native: %+v, %v", r, err)
+	}
+	r, err = cryptocoin.ParsePaymentURI("ethereum:0x89205a3a3b2a69de6dbf7f01ed13b2108b2c43e7@137/transfer?address=0x8e23ee67d1332ad560396262c48ffbb01f93d052&uint256=1")
+	if err != nil || r.Network != cryptocurrency.PolygonNetwork || r.Token != "0x89205A3A3b2A69De6Dbf7f01ED13B2108B2c43e7" ||
+		r.Address != "0x8e23Ee67d1332aD560396262C48ffbB01F93D052" || r.Amount.Int64() != 1 {
+		t.Fatalf("EIP-681 transfer: %+v, %v", r, err)
+	}
+
+	bad := []struct {
+		uri    string
+		reason error
+	}{
+		{"bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?req-somethingyoudontunderstand=50", cryptocoin.ErrPaymentURI},
+		{"bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=0.000000001", cryptocoin.ErrPaymentURI},
+		{"bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=1e3", cryptocoin.ErrPaymentURI},
+		{"ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@999999", cryptocoin.ErrUnknownNetwork},
+		{"ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed?value=1.5", cryptocoin.ErrPaymentURI},
+		{"ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", cryptocoin.ErrAddressChecksum},
+		{"tron:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", cryptocoin.ErrAddressOtherNetwork},
+		{"ton://transfer/0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8?amount=-1", cryptocoin.ErrPaymentURI},
+		{"litecoin:ltc1qg82vdwj8mrqw5hcvwqswhqnkpq3fkpgcs7u6y3", cryptocoin.ErrPaymentURI},
+	}
+	for _, tc := range bad {
+		if _, err := cryptocoin.ParsePaymentURI(tc.uri); !errors.Is(err, tc.reason) {
+			t.Errorf("ParsePaymentURI(%q) error = %v; want %v", tc.uri, err, tc.reason)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package cryptocoin_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/bizvip/go-utils/cryptocoin"
+)
+
+// signCompact 用 secp256k1 库签名，并把 [27+v]||r||s 调整为以太坊的 r||s||v 顺序。
+func signCompact(priv, hash []byte) string {
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(priv), hash, false)
+	return "0x" + hex.EncodeToString(append(compact[1:], compact[0]))
+}
+
+func TestPersonalSignAndTronMessage(t *testing.T) {
+	master, _ := cryptocoin.NewMasterKey(mustSeed(t, testMnemonic))
+	key, _ := master.Derive(cryptocoin.AddressPath(cryptocoin.PurposeBIP44, cryptocoin.CoinTypeETH, 0, 0, 0))
+	msg := []byte("Sign in to example.com\nNonce: 8f3a1c")
+
+	sig := signCompact(key.PrivateKey(), cryptocoin.PersonalMessageHash(msg))
+	got, err := cryptocoin.RecoverPersonalSign(msg, sig)
+	if err != nil || got != key.EVMAddress() {
+		t.Fatalf("RecoverPersonalSign = %s, %v; want %s", got, err, key.EVMAddress())
+	}
+	lower, _ := cryptocoin.ToLowerEVMAddress(key.EVMAddress())
+	if err := cryptocoin.VerifyPersonalSign(lower, msg, sig); err != nil {
+		t.Fatalf("VerifyPersonalSign: %v", err)
+	}
+	if err := cryptocoin.VerifyPersonalSign(lower, []byte("tampered"), sig); !errors.Is(err, cryptocoin.ErrSignatureMismatch) {
+		t.Fatalf("tampered message: %v", err)
+	}
+	if _, err := cryptocoin.RecoverPersonalSign(msg, sig[:20]); !errors.Is(err, cryptocoin.ErrSignatureFormat) {
+		t.Fatalf("short signature: %v", err)
+	}
+
+	tronSig := signCompact(key.PrivateKey(), cryptocoin.TronMessageHash(msg))
+	if err := cryptocoin.VerifyTronMessage(key.TronAddress(), msg, tronSig); err != nil {
+		t.Fatalf("VerifyTronMessage: %v", err)
+	}
+	// personal_sign 的签名不能冒充 Tron 签名
+	if err := cryptocoin.VerifyTronMessage(key.TronAddress(), msg, sig); !errors.Is(err, cryptocoin.ErrSignatureMismatch) {
+		t.Fatalf("cross-chain replay: %v", err)
+	}
+}
+
+// web3.js 文档中 web3.eth.accounts.sign 的示例：消息 "Some data"，
+// 私钥 0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318。
+func TestPersonalSignKnownVector(t *testing.T) {
+	const (
+		address = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+		sig     = "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd" +
+			"6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a029" + "1c"
+	)
+	msg := []byte("Some data")
+	if h := hex.EncodeToString(cryptocoin.PersonalMessageHash(msg)); h != "1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655" {
+		t.Fatalf("PersonalMessageHash = %s", h)
+	}
+	if got, err := cryptocoin.RecoverPersonalSign(msg, sig); err != nil || got != address {
+		t.Fatalf("RecoverPersonalSign = %s, %v; want %s", got, err, address)
+	}
+	if err := cryptocoin.VerifyPersonalSign(address, []byte("Some date"), sig); !errors.Is(err, cryptocoin.ErrSignatureMismatch) {
+		t.Fatalf("tampered message: %v", err)
+	}
+}
+
+// EIP-712 规范中的 Mail 示例，签名私钥为 keccak256("cow")。
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestEIP712MailExample(t *testing.T) {
+	td, err := cryptocoin.ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		t.Fatalf("ParseTypedData: %v", err)
+	}
+	enc, _ := td.EncodeType("Mail")
+	if enc != "Mail(Person from,Person to,string contents)Person(string name,address wallet)" {
+		t.Fatalf("EncodeType = %s", enc)
+	}
+	h, err := td.Hash()
+	if err != nil || hex.EncodeToString(h) != "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Fatalf("Hash = %x, %v", h, err)
+	}
+
+	sig := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if err := cryptocoin.VerifyTypedData("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", td, sig); err != nil {
+		t.Fatalf("VerifyTypedData: %v", err)
+	}
+	td.Message["contents"] = "Hello, Eve!"
+	if err := cryptocoin.VerifyTypedData("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", td, sig); !errors.Is(err, cryptocoin.ErrSignatureMismatch) {
+		t.Fatalf("tampered typed data: %v", err)
+	}
+}
+
+func TestVerifyTONProof(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+
+	// StateInit{code, data}：code 为任意 cell，data 为 wallet v4 布局
+	code := []byte{0x00, 0x04, 0xab, 0xcd}
+	data := []byte{0x00, 0x51, 0, 0, 0, 0}
+	data = append(data, 0x29, 0xa9, 0xa3, 0x17) // TONWalletDefaultID
+	data = append(data, pub...)
+	data = append(data, 0x40)
+	boc := []byte{0xb5, 0xee, 0x9c, 0x72, 0x01, 0x01, 0x03, 0x01, 0x00, byte(5 + len(code) + len(data)), 0x00}
+	boc = append(boc, 0x02, 0x01, 0x34, 0x01, 0x02)
+	boc = append(append(boc, code...), data...)
+
+	codeInfo, err := cryptocoin.ParseTONWalletCode(append([]byte{0xb5, 0xee, 0x9c, 0x72, 0x01, 0x01, 0x01, 0x01, 0x00, 0x04, 0x00}, code...))
+	if err != nil {
+		t.Fatalf("ParseTONWalletCode: %v", err)
+	}
+	addr, _ := cryptocoin.TONWalletV4Address(codeInfo, pub, cryptocoin.TONWalletDefaultID)
+
+	proof := cryptocoin.TONProof{
+		Address:   addr.Raw(),
+		Domain:    "example.com",
+		Timestamp: time.Now().Unix(),
+		Payload:   "nonce-123",
+		StateInit: base64.StdEncoding.EncodeToString(boc),
+	}
+	h, err := proof.SignedHash()
+	if err != nil {
+		t.Fatalf("SignedHash: %v", err)
+	}
+	proof.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, h[:]))
+
+	if err := cryptocoin.VerifyTONProof(proof, pub, "example.com", 15*time.Minute); err != nil {
+		t.Fatalf("VerifyTONProof: %v", err)
+	}
+	if err := cryptocoin.VerifyTONProof(proof, pub, "evil.com", 15*time.Minute); !errors.Is(err, cryptocoin.ErrTONProofDomain) {
+		t.Fatalf("domain check: %v", err)
+	}
+
+	// 攻击者用自己的公钥冒充该地址：StateInit 中找不到其公钥
+	otherPub, otherPriv, _ := ed25519.GenerateKey(nil)
+	forged := proof
+	forged.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(otherPriv, h[:]))
+	if err := cryptocoin.VerifyTONProof(forged, otherPub, "example.com", 15*time.Minute); !errors.Is(err, cryptocoin.ErrTONProofStateInit) {
+		t.Fatalf("forged public key: %v", err)
+	}
+
+	stale := proof
+	stale.Timestamp = time.Now().Add(-time.Hour).Unix()
+	if err := cryptocoin.VerifyTONProof(stale, pub, "example.com", 15*time.Minute); !errors.Is(err, cryptocoin.ErrTONProofExpired) {
+		t.Fatalf("stale proof: %v", err)
+	}
+}
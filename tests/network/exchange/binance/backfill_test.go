@@ -0,0 +1,163 @@
+package binance_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+// klineServer 按分钟生成K线，missing 中的开盘时间视为维护期间没有数据。
+func klineServer(t *testing.T, calls *atomic.Int32, missing map[int64]bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		const minute = int64(time.Minute / time.Millisecond)
+
+		var rows []string
+		for open := (start + minute - 1) / minute * minute; open <= end && len(rows) < limit; open += minute {
+			if missing[open] {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf(`[%d,"1.0","1.2","0.9","1.1","10",%d,"11",5,"4","4.4","0"]`, open, open+minute-1))
+		}
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "2")
+		_, _ = w.Write([]byte("[" + strings.Join(rows, ",") + "]"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBackfillResumeAndGaps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	missing := map[int64]bool{start.Add(4 * time.Minute).UnixMilli(): true}
+
+	var calls atomic.Int32
+	srv := klineServer(t, &calls, missing)
+	store, err := binance.NewCSVStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := binance.NewBackfiller(&binance.MarketService{Pool: binance.NewEndpointPool(srv.Client(), srv.URL)}, store)
+	b.Limit = 3
+
+	// 先回补前 5 分钟，再续传到 end
+	n, err := b.Run(context.Background(), "BTCUSDT", "1m", start, start.Add(5*time.Minute))
+	if err != nil || n != 4 {
+		t.Fatalf("first run stored %d, err = %v", n, err)
+	}
+	var progress []binance.BackfillProgress
+	b.OnProgress = func(p binance.BackfillProgress) { progress = append(progress, p) }
+	n, err = b.Run(context.Background(), "BTCUSDT", "1m", start, end)
+	if err != nil || n != 5 {
+		t.Fatalf("second run stored %d, err = %v", n, err)
+	}
+	if len(progress) == 0 || !progress[len(progress)-1].Through.Equal(end.Add(-time.Minute)) {
+		t.Fatalf("progress = %+v", progress)
+	}
+
+	klines, err := store.Load("BTCUSDT", "1m")
+	if err != nil || len(klines) != 9 {
+		t.Fatalf("loaded %d klines, err = %v", len(klines), err)
+	}
+	for i := 1; i < len(klines); i++ {
+		if klines[i].OpenTime <= klines[i-1].OpenTime {
+			t.Fatalf("klines not increasing at %d", i)
+		}
+	}
+	if k := klines[0]; k.Close.String() != "1.1" || k.Trades != 5 || k.TakerBuyQuoteVolume.String() != "4.4" {
+		t.Fatalf("kline = %+v", k)
+	}
+
+	gaps, err := store.Gaps("BTCUSDT", "1m")
+	if err != nil || len(gaps) != 1 {
+		t.Fatalf("gaps = %+v, err = %v", gaps, err)
+	}
+	if g := gaps[0]; !g.From.Equal(start.Add(4*time.Minute)) || g.Count != 1 {
+		t.Fatalf("gap = %+v", g)
+	}
+
+	// 已全部回补，再次运行不写入任何数据
+	before := calls.Load()
+	if n, err := b.Run(context.Background(), "BTCUSDT", "1m", start, end); err != nil || n != 0 || calls.Load() != before {
+		t.Fatalf("third run stored %d, calls %d, err = %v", n, calls.Load()-before, err)
+	}
+}
+
+func TestBackfillRecoversPartialRow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(6 * time.Minute)
+
+	var calls atomic.Int32
+	srv := klineServer(t, &calls, nil)
+	store, err := binance.NewCSVStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := binance.NewBackfiller(&binance.MarketService{Pool: binance.NewEndpointPool(srv.Client(), srv.URL)}, store)
+	if n, err := b.Run(context.Background(), "BTCUSDT", "1m", start, start.Add(3*time.Minute)); err != nil || n != 3 {
+		t.Fatalf("first run stored %d, err = %v", n, err)
+	}
+
+	// 模拟 Append 写到一半进程退出：末尾留下没有换行符的半行
+	f, err := os.OpenFile(store.Path("BTCUSDT", "1m"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fmt.Fprintf(f, "%d,1.0,1.2", start.Add(3*time.Minute).UnixMilli())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last, ok, err := store.Last("BTCUSDT", "1m")
+	if err != nil || !ok || last.OpenTime != start.Add(2*time.Minute).UnixMilli() {
+		t.Fatalf("Last = %+v, %v, %v", last, ok, err)
+	}
+	n, err := b.Run(context.Background(), "BTCUSDT", "1m", start, end)
+	if err != nil || n != 3 {
+		t.Fatalf("resumed run stored %d, err = %v", n, err)
+	}
+	klines, err := store.Load("BTCUSDT", "1m")
+	if err != nil || len(klines) != 6 {
+		t.Fatalf("loaded %d klines, err = %v", len(klines), err)
+	}
+	for i, k := range klines {
+		if want := start.Add(time.Duration(i) * time.Minute).UnixMilli(); k.OpenTime != want {
+			t.Fatalf("kline %d open = %d, want %d", i, k.OpenTime, want)
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	iv, err := binance.ParseInterval("1M")
+	if err != nil || iv.Months != 1 {
+		t.Fatalf("1M = %+v, %v", iv, err)
+	}
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := iv.Next(jan1); !got.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Next = %s", got)
+	}
+	if iv, err := binance.ParseInterval("15m"); err != nil || iv.Duration != 15*time.Minute {
+		t.Fatalf("15m = %+v, %v", iv, err)
+	}
+	for _, bad := range []string{"", "m", "0h", "5x", "-1d"} {
+		if _, err := binance.ParseInterval(bad); err == nil {
+			t.Fatalf("ParseInterval(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package binance_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+// deadURL 返回一个已关闭服务的地址，连接会被立即拒绝。
+func deadURL() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func countingServer(t *testing.T, hits *atomic.Int32, h http.HandlerFunc) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func serverTime(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-MBX-USED-WEIGHT-1M", "7")
+	_, _ = w.Write([]byte(`{"serverTime":1700000000000}`))
+}
+
+func TestEndpointPoolSticksToHealthyHost(t *testing.T) {
+	var hits atomic.Int32
+	good := countingServer(t, &hits, serverTime)
+	pool := binance.NewEndpointPool(nil, deadURL(), good.URL)
+	m := &binance.MarketService{Pool: pool}
+
+	for i := 0; i < 3; i++ {
+		got, err := m.GetServerTime(context.Background())
+		if err != nil {
+			t.Fatalf("GetServerTime: %v", err)
+		}
+		if got.UnixMilli() != 1700000000000 {
+			t.Fatalf("server time = %d", got.UnixMilli())
+		}
+	}
+	if hits.Load() != 3 {
+		t.Fatalf("healthy host hits = %d, want 3", hits.Load())
+	}
+	stats := pool.Stats()
+	if stats[0].Failures != 1 || stats[0].Preferred || !stats[1].Preferred || stats[1].Latency == 0 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if u := pool.Usage(); u.UsedWeight["1m"] != 7 {
+		t.Fatalf("usage = %+v", u)
+	}
+}
+
+func TestEndpointPoolCircuitBreaker(t *testing.T) {
+	var badHits, goodHits atomic.Int32
+	bad := countingServer(t, &badHits, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	good := countingServer(t, &goodHits, serverTime)
+	pool := binance.NewEndpointPool(nil, bad.URL, good.URL)
+	m := &binance.MarketService{Pool: pool}
+
+	for i := 0; i < 5; i++ {
+		if _, err := m.GetServerTime(context.Background()); err != nil {
+			t.Fatalf("GetServerTime: %v", err)
+		}
+	}
+	// 第一次失败后粘滞到健康域名，故障域名之后不再被访问
+	if badHits.Load() != 1 || goodHits.Load() != 5 {
+		t.Fatalf("bad hits = %d, good hits = %d", badHits.Load(), goodHits.Load())
+	}
+
+	// 只有故障域名时连续失败达到阈值即熔断
+	pool = binance.NewEndpointPool(nil, bad.URL)
+	m = &binance.MarketService{Pool: pool}
+	for i := 0; i < 3; i++ {
+		var apiErr *binance.APIError
+		if _, err := m.GetServerTime(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("err = %v", err)
+		}
+	}
+	if st := pool.Stats()[0]; st.Failures != 3 || !st.OpenUntil.After(time.Now()) {
+		t.Fatalf("stats = %+v", st)
+	}
+}
+
+func TestEndpointPoolRateLimitBan(t *testing.T) {
+	var hits atomic.Int32
+	srv := countingServer(t, &hits, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":-1003,"msg":"Too many requests."}`))
+	})
+	var otherHits atomic.Int32
+	other := countingServer(t, &otherHits, serverTime)
+	m := &binance.MarketService{Pool: binance.NewEndpointPool(nil, srv.URL, other.URL)}
+
+	var apiErr *binance.APIError
+	if _, err := m.GetServerTime(context.Background()); !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if !apiErr.RateLimited() || apiErr.Code != -1003 || apiErr.RetryAfter != 120*time.Second {
+		t.Fatalf("apiErr = %+v", apiErr)
+	}
+
+	// 封禁期间直接返回，不再访问任何域名
+	_, err := m.GetServerTime(context.Background())
+	if !errors.As(err, &apiErr) || !apiErr.RateLimited() || apiErr.RetryAfter <= 0 {
+		t.Fatalf("err = %v", err)
+	}
+	if hits.Load() != 1 || otherHits.Load() != 0 {
+		t.Fatalf("hits = %d, other hits = %d", hits.Load(), otherHits.Load())
+	}
+}
+
+func TestEndpointPoolClientErrorNoFallback(t *testing.T) {
+	var hits, otherHits atomic.Int32
+	srv := countingServer(t, &hits, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+	})
+	other := countingServer(t, &otherHits, serverTime)
+	m := &binance.MarketService{Pool: binance.NewEndpointPool(nil, srv.URL, other.URL)}
+
+	var apiErr *binance.APIError
+	if _, err := m.GetAvgPrice(context.Background(), "NOPE"); !errors.As(err, &apiErr) || apiErr.Code != -1121 {
+		t.Fatalf("err = %v", err)
+	}
+	if hits.Load() != 1 || otherHits.Load() != 0 {
+		t.Fatalf("hits = %d, other hits = %d", hits.Load(), otherHits.Load())
+	}
+}
+
+func TestSpotServiceSignedRequest(t *testing.T) {
+	var timeCalls, accountCalls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/time", func(w http.ResponseWriter, r *http.Request) {
+		timeCalls.Add(1)
+		_, _ = w.Write([]byte(`{"serverTime":` + strconv.FormatInt(time.Now().UnixMilli(), 10) + `}`))
+	})
+	mux.HandleFunc("/api/v3/account", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-MBX-APIKEY") != "key" {
+			t.Errorf("api key header = %q", r.Header.Get("X-MBX-APIKEY"))
+		}
+		q := r.URL.RawQuery
+		if !strings.Contains(q, "timestamp=") || !strings.Contains(q, "&signature=") {
+			t.Errorf("query = %s", q)
+		}
+		// 第一次返回 -1021，促使客户端重新校时后重试
+		if accountCalls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`))
+			return
+		}
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "20")
+		_, _ = w.Write([]byte(`{"canTrade":true,"balances":[{"asset":"BTC","free":"0.5","locked":"0"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	pool := binance.NewEndpointPool(srv.Client(), srv.URL)
+	s := binance.NewSpotService("key", binance.NewHMACSigner("secret"))
+	s.Pool = pool
+
+	acc, err := s.GetAccount(context.Background(), false)
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if b := acc.Balance("BTC"); b.Free.String() != "0.5" {
+		t.Fatalf("balance = %+v", acc.Balances)
+	}
+	if timeCalls.Load() != 2 || accountCalls.Load() != 2 {
+		t.Fatalf("time calls = %d, account calls = %d", timeCalls.Load(), accountCalls.Load())
+	}
+	if u := pool.Usage(); u.UsedWeight["1m"] != 20 {
+		t.Fatalf("usage = %+v", u)
+	}
+}
@@ -0,0 +1,41 @@
+package binance_test
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+// Binance API 文档中的 HMAC-SHA256 签名示例。
+func TestHMACSigner(t *testing.T) {
+	s := binance.NewHMACSigner("NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j")
+	payload := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+	if got := s.Sign(payload); got != "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71" {
+		t.Fatalf("Sign = %s", got)
+	}
+}
+
+func TestEd25519SignerFromPEM(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := binance.ParseEd25519PrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParseEd25519PrivateKeyPEM: %v", err)
+	}
+
+	payload := "symbol=BTCUSDT&timestamp=1668481559918"
+	sig, err := base64.StdEncoding.DecodeString(binance.NewEd25519Signer(key).Sign(payload))
+	if err != nil || !ed25519.Verify(pub, []byte(payload), sig) {
+		t.Fatalf("signature does not verify: %v", err)
+	}
+	if _, err := binance.ParseEd25519PrivateKeyPEM([]byte("not a pem")); err == nil {
+		t.Fatal("expected error for invalid PEM")
+	}
+}
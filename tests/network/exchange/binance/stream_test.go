@@ -0,0 +1,160 @@
+package binance_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/net/websocket"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+// newStreamServer 每个连接依次推送 perConn 中对应的消息后断开，用于验证重连。
+func newStreamServer(t *testing.T, perConn [][]string) (*httptest.Server, *atomic.Int32, chan string) {
+	t.Helper()
+	var conns atomic.Int32
+	queries := make(chan string, 16)
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		n := int(conns.Add(1)) - 1
+		queries <- ws.Request().URL.RawQuery
+		if n >= len(perConn) {
+			time.Sleep(time.Second)
+			return
+		}
+		// 先发一次 ping，客户端应自动回复 pong 而不影响读取
+		ws.PayloadType = websocket.PingFrame
+		_, _ = ws.Write([]byte("ping"))
+		ws.PayloadType = websocket.TextFrame
+		for _, m := range perConn[n] {
+			if err := websocket.Message.Send(ws, m); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &conns, queries
+}
+
+func testStreamClient(srv *httptest.Server) *binance.StreamClient {
+	c := binance.NewStreamClient()
+	c.BaseURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	c.MinBackoff, c.MaxBackoff = 10*time.Millisecond, 50*time.Millisecond
+	c.OnError = func(error) {}
+	return c
+}
+
+func TestStreamClientDispatchAndReconnect(t *testing.T) {
+	srv, conns, queries := newStreamServer(t, [][]string{
+		{`{"stream":"btcusdt@trade","data":{"e":"trade","E":1,"s":"BTCUSDT","t":12345,"p":"0.001","q":"100","T":2,"m":true,"M":false}}`},
+		{
+			`{"stream":"btcusdt@kline_1m","data":{"e":"kline","E":3,"s":"BTCUSDT","k":{"t":60000,"T":119999,"s":"BTCUSDT","i":"1m","o":"1","c":"2","h":"3","l":"0.5","v":"10","n":7,"x":true,"q":"15","V":"4","Q":"6","B":"0"}}}`,
+			`{"stream":"!miniTicker@arr","data":[{"e":"24hrMiniTicker","E":4,"s":"ETHUSDT","c":"3000"},{"e":"24hrMiniTicker","E":4,"s":"BNBUSDT","c":"600"}]}`,
+		},
+	})
+
+	var (
+		trades  []binance.TradeEvent
+		klines  []binance.KlineEvent
+		tickers []binance.MiniTickerEvent
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	h := binance.StreamHandlers{
+		Trade: func(ev binance.TradeEvent) { trades = append(trades, ev) },
+		Kline: func(ev binance.KlineEvent) { klines = append(klines, ev) },
+		MiniTicker: func(ev binance.MiniTickerEvent) {
+			if tickers = append(tickers, ev); len(tickers) == 2 {
+				cancel()
+			}
+		},
+	}
+	streams := []string{binance.TradeStream("BTCUSDT"), binance.KlineStream("BTCUSDT", "1m"), binance.AllMiniTickersStream}
+	if err := testStreamClient(srv).Run(ctx, streams, h.Handle); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v; want context.Canceled", err)
+	}
+
+	if got := <-queries; got != "streams=btcusdt@trade/btcusdt@kline_1m/!miniTicker@arr" {
+		t.Fatalf("query = %q", got)
+	}
+	if conns.Load() != 2 {
+		t.Fatalf("connections = %d; want 2 (one reconnect)", conns.Load())
+	}
+	// "M" 不得覆盖 "m"
+	if len(trades) != 1 || trades[0].TradeID != 12345 || !trades[0].IsBuyerMaker || !trades[0].Price.Equal(decimal.RequireFromString("0.001")) {
+		t.Fatalf("trades = %+v", trades)
+	}
+	if len(klines) != 1 || !klines[0].Kline.IsFinal || klines[0].Kline.Trades != 7 || !klines[0].Kline.Low.Equal(decimal.RequireFromString("0.5")) {
+		t.Fatalf("klines = %+v", klines)
+	}
+	if tickers[1].Symbol != "BNBUSDT" {
+		t.Fatalf("tickers = %+v", tickers)
+	}
+}
+
+func level(price, qty string) binance.PriceLevel {
+	return binance.PriceLevel{Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString(qty)}
+}
+
+func TestOrderBookSync(t *testing.T) {
+	snapshots := []binance.Depth{
+		{LastUpdateID: 5}, // 落后于第一条增量，须重新拉取
+		{LastUpdateID: 100, Bids: []binance.PriceLevel{level("10", "1"), level("9", "2")}, Asks: []binance.PriceLevel{level("11", "1"), level("12", "3")}},
+		{LastUpdateID: 300, Bids: []binance.PriceLevel{level("20", "1")}, Asks: []binance.PriceLevel{level("21", "1")}},
+	}
+	var calls int
+	snapshot := func(_ context.Context, symbol string, limit int) (binance.Depth, error) {
+		if symbol != "BTCUSDT" || limit != 1000 {
+			t.Fatalf("snapshot(%s, %d)", symbol, limit)
+		}
+		calls++
+		return snapshots[calls-1], nil
+	}
+	book := binance.NewOrderBook("BTCUSDT", snapshot, 0)
+	ctx := context.Background()
+
+	if _, ok := book.BestBid(); ok {
+		t.Fatal("unsynced book must not report prices")
+	}
+	steps := []binance.DepthEvent{
+		{FirstUpdateID: 90, FinalUpdateID: 95, Bids: []binance.PriceLevel{level("10", "99")}}, // 早于快照，丢弃
+		{FirstUpdateID: 96, FinalUpdateID: 102, Bids: []binance.PriceLevel{level("10", "0"), level("9.5", "4")}, Asks: []binance.PriceLevel{level("11.5", "2")}},
+		{FirstUpdateID: 103, FinalUpdateID: 104, Asks: []binance.PriceLevel{level("11", "0")}},
+	}
+	for _, ev := range steps {
+		if err := book.Apply(ctx, ev); err != nil {
+			t.Fatalf("Apply(%d-%d): %v", ev.FirstUpdateID, ev.FinalUpdateID, err)
+		}
+	}
+	d, ok := book.Depth(0)
+	if !ok || d.LastUpdateID != 104 || calls != 2 {
+		t.Fatalf("depth = %+v, synced %v, snapshot calls %d", d, ok, calls)
+	}
+	wantBids, wantAsks := []string{"9.5", "9"}, []string{"11.5", "12"}
+	for i, l := range d.Bids {
+		if l.Price.String() != wantBids[i] {
+			t.Fatalf("bids = %+v", d.Bids)
+		}
+	}
+	for i, l := range d.Asks {
+		if l.Price.String() != wantAsks[i] {
+			t.Fatalf("asks = %+v", d.Asks)
+		}
+	}
+
+	// 丢包：U 不等于上一条的 u+1，重新拉取快照
+	if err := book.Apply(ctx, binance.DepthEvent{FirstUpdateID: 250, FinalUpdateID: 301, Bids: []binance.PriceLevel{level("19", "5")}}); err != nil {
+		t.Fatalf("Apply after gap: %v", err)
+	}
+	if bid, _ := book.BestBid(); calls != 3 || bid.Price.String() != "20" {
+		t.Fatalf("after resync best bid = %+v, snapshot calls %d", bid, calls)
+	}
+	if d, _ := book.Depth(1); len(d.Bids) != 1 || d.LastUpdateID != 301 {
+		t.Fatalf("depth(1) = %+v", d)
+	}
+}
@@ -0,0 +1,61 @@
+package binance_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+func TestDepthUnmarshal(t *testing.T) {
+	raw := `{"lastUpdateId":1027024,"bids":[["4.00000000","431.00000000"]],"asks":[["4.00000200","12.00000000"],["4.10000000","1.5"]]}`
+	var d binance.Depth
+	if err := json.Unmarshal([]byte(raw), &d); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if d.LastUpdateID != 1027024 || len(d.Bids) != 1 || len(d.Asks) != 2 {
+		t.Fatalf("unexpected depth %+v", d)
+	}
+	if !d.Asks[0].Price.Equal(decimal.RequireFromString("4.000002")) || !d.Bids[0].Qty.Equal(decimal.NewFromInt(431)) {
+		t.Fatalf("unexpected levels %+v %+v", d.Bids[0], d.Asks[0])
+	}
+}
+
+func TestKlineUnmarshal(t *testing.T) {
+	raw := `[[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"]]`
+	var ks []binance.Kline
+	if err := json.Unmarshal([]byte(raw), &ks); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	k := ks[0]
+	if k.OpenTime != 1499040000000 || k.CloseTime != 1499644799999 || k.Trades != 308 ||
+		!k.High.Equal(decimal.RequireFromString("0.8")) || !k.TakerBuyQuoteVolume.Equal(decimal.RequireFromString("28.46694368")) {
+		t.Fatalf("unexpected kline %+v", k)
+	}
+	if err := json.Unmarshal([]byte(`[1499040000000,"1"]`), &k); err == nil {
+		t.Fatal("expected error for short kline")
+	}
+}
+
+func TestExchangeInfoFilters(t *testing.T) {
+	raw := `{"timezone":"UTC","serverTime":1565246363776,"symbols":[{"symbol":"ETHBTC","status":"TRADING","baseAsset":"ETH","quoteAsset":"BTC",
+		"filters":[{"filterType":"PRICE_FILTER","minPrice":"0.00000100","maxPrice":"100000.00000000","tickSize":"0.00000100"},
+		{"filterType":"LOT_SIZE","minQty":"0.00100000","maxQty":"100000.00000000","stepSize":"0.00100000"}]}]}`
+	var info binance.ExchangeInfo
+	if err := json.Unmarshal([]byte(raw), &info); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	s, ok := info.Symbol("ETHBTC")
+	if !ok {
+		t.Fatal("ETHBTC not found")
+	}
+	lot, ok := s.Filter("LOT_SIZE")
+	if !ok || !lot.StepSize.Equal(decimal.RequireFromString("0.001")) || !lot.TickSize.IsZero() {
+		t.Fatalf("unexpected LOT_SIZE %+v", lot)
+	}
+	if _, ok := s.Filter("ICEBERG_PARTS"); ok {
+		t.Fatal("unexpected ICEBERG_PARTS filter")
+	}
+}
@@ -0,0 +1,259 @@
+package binance_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/net/websocket"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+)
+
+// Binance 文档中的 executionReport 示例，含自成交保护字段 "v"（与 "V" 仅大小写不同）。
+const docExecutionReport = `{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC",` +
+	`"q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"","x":"NEW","X":"NEW","r":"NONE",` +
+	`"i":4293153,"l":"0.00000000","z":"0.00000000","L":"0.00000000","n":"0","N":null,"T":1499405658657,"t":-1,"v":3,` +
+	`"I":8641984,"w":true,"m":false,"M":false,"O":1499405658657,"Z":"0.00000000","Y":"0.00000000","Q":"0.00000000",` +
+	`"W":1499405658657,"V":"NONE"}`
+
+// 成交回报：i / I、m / M 取值不同，用于确认大小写键互不覆盖。
+const tradeExecutionReport = `{"e":"executionReport","E":1700000000100,"s":"BTCUSDT","c":"my-order-1","S":"SELL","o":"LIMIT","f":"GTC",` +
+	`"q":"0.01000000","p":"30000.00","P":"0","F":"0","g":-1,"C":"","x":"TRADE","X":"FILLED","r":"NONE",` +
+	`"i":42,"l":"0.01000000","z":"0.01000000","L":"30000.00","n":"0.3","N":"USDT","T":1700000000099,"t":777,` +
+	`"I":99,"w":false,"m":true,"M":false,"O":1700000000000,"Z":"300.00","Y":"300.00","Q":"0","W":1700000000000,"V":"EXPIRE_MAKER"}`
+
+const accountPosition = `{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,"B":[{"a":"ETH","f":"10000.000000","l":"0.000000"},{"a":"USDT","f":"12.5","l":"7.5"}]}`
+
+// userDataServer 同时提供 REST（listenKey 的创建与续期）与 WebSocket：
+// 第 n 次创建返回 key-n，订阅 key-n 的连接依次收到 perKey[n-1] 中的事件后保持连接，直到客户端断开。
+type userDataServer struct {
+	*httptest.Server
+	creates    atomic.Int32
+	keepAlives chan string // 续期请求中的 listenKey
+	queries    chan string // WebSocket 连接的查询串
+}
+
+func newUserDataServer(t *testing.T, keepAliveStatus int, perKey [][]string) *userDataServer {
+	t.Helper()
+	s := &userDataServer{keepAlives: make(chan string, 64), queries: make(chan string, 16)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/userDataStream", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-MBX-APIKEY") != "key" {
+			t.Errorf("api key header = %q", r.Header.Get("X-MBX-APIKEY"))
+		}
+		switch r.Method {
+		case http.MethodPost:
+			n := s.creates.Add(1)
+			_, _ = w.Write([]byte(`{"listenKey":"key-` + strconv.Itoa(int(n)) + `"}`))
+		case http.MethodPut:
+			select {
+			case s.keepAlives <- r.URL.Query().Get("listenKey"):
+			default:
+			}
+			if keepAliveStatus != http.StatusOK {
+				w.WriteHeader(keepAliveStatus)
+				_, _ = w.Write([]byte(`{"code":-1125,"msg":"This listenKey does not exist."}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	mux.Handle("/stream", websocket.Handler(func(ws *websocket.Conn) {
+		key := ws.Request().URL.Query().Get("streams")
+		s.queries <- key
+		n, _ := strconv.Atoi(strings.TrimPrefix(key, "key-"))
+		if n >= 1 && n <= len(perKey) {
+			for _, ev := range perKey[n-1] {
+				if err := websocket.Message.Send(ws, `{"stream":"`+key+`","data":`+ev+`}`); err != nil {
+					return
+				}
+			}
+		}
+		var discard string
+		_ = websocket.Message.Receive(ws, &discard)
+	}))
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *userDataServer) spot() *binance.SpotService {
+	spot := binance.NewSpotService("key", nil)
+	spot.Pool = binance.NewEndpointPool(s.Client(), s.URL)
+	return spot
+}
+
+func TestUserDataStreamDispatchAndExpiry(t *testing.T) {
+	srv := newUserDataServer(t, http.StatusOK, [][]string{
+		{accountPosition, docExecutionReport, `{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"100.00000000","T":1573200697068}`, `{"e":"listenKeyExpired","E":1576653824250}`},
+		{tradeExecutionReport},
+	})
+
+	var (
+		accounts []binance.AccountUpdateEvent
+		balances []binance.BalanceUpdateEvent
+		orders   []binance.ExecutionReport
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	h := binance.UserDataHandlers{
+		Account: func(ev binance.AccountUpdateEvent) { accounts = append(accounts, ev) },
+		Balance: func(ev binance.BalanceUpdateEvent) { balances = append(balances, ev) },
+		Order: func(ev binance.ExecutionReport) {
+			if orders = append(orders, ev); len(orders) == 2 {
+				cancel()
+			}
+		},
+	}
+	c := binance.NewStreamClient()
+	c.BaseURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	c.MinBackoff, c.MaxBackoff = 10*time.Millisecond, 50*time.Millisecond
+	c.OnError = func(err error) {
+		if strings.Contains(err.Error(), "user data") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if err := srv.spot().RunUserDataStream(ctx, c, h); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunUserDataStream = %v; want context.Canceled", err)
+	}
+
+	// listenKeyExpired 后重新创建 listenKey 并以新 key 重连
+	if got := srv.creates.Load(); got != 2 {
+		t.Fatalf("listenKey creates = %d; want 2", got)
+	}
+	if q1, q2 := <-srv.queries, <-srv.queries; q1 != "key-1" || q2 != "key-2" {
+		t.Fatalf("subscribed %q then %q", q1, q2)
+	}
+
+	if len(accounts) != 1 || accounts[0].LastUpdateTime != 1564034571073 || len(accounts[0].Balances) != 2 {
+		t.Fatalf("accounts = %+v", accounts)
+	}
+	if b := accounts[0].Balances[1]; b.Asset != "USDT" || b.Free.String() != "12.5" || b.Locked.String() != "7.5" {
+		t.Fatalf("balance = %+v", b)
+	}
+	if len(balances) != 1 || balances[0].Asset != "BTC" || balances[0].Delta.String() != "100" || balances[0].ClearTime != 1573200697068 {
+		t.Fatalf("balance updates = %+v", balances)
+	}
+
+	doc := orders[0]
+	if doc.OrderID != 4293153 || doc.ReservedI != 8641984 || doc.PreventedMatchID != 3 || doc.SelfTradePreventionMode != "NONE" ||
+		doc.Price.String() != "0.1026441" || doc.OrderStatus != binance.OrderStatusNew || !doc.IsWorking || doc.CommissionAsset != "" {
+		t.Fatalf("doc execution report = %+v", doc)
+	}
+	trade := orders[1]
+	if trade.OrderID != 42 || trade.ReservedI != 99 || !trade.IsMaker || trade.ReservedM || trade.TradeID != 777 ||
+		trade.Side != binance.SideSell || trade.OrderStatus != binance.OrderStatusFilled || trade.ExecutionType != "TRADE" ||
+		trade.LastExecutedPrice.String() != "30000" || trade.Commission.String() != "0.3" || trade.CommissionAsset != "USDT" {
+		t.Fatalf("trade execution report = %+v", trade)
+	}
+}
+
+func TestUserDataStreamKeepAliveFailure(t *testing.T) {
+	srv := newUserDataServer(t, http.StatusBadRequest, nil)
+	spot := srv.spot()
+	spot.KeepAliveInterval = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	keepAliveErrs := make(chan error, 16)
+	c := binance.NewStreamClient()
+	c.BaseURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	c.MinBackoff, c.MaxBackoff = 10*time.Millisecond, 50*time.Millisecond
+	c.OnError = func(err error) {
+		if strings.Contains(err.Error(), "keepalive") {
+			select {
+			case keepAliveErrs <- err:
+			default:
+			}
+		}
+	}
+	// 订阅到第二个 listenKey 即说明续期失败后已重建
+	go func() {
+		for q := range srv.queries {
+			if q == "key-2" {
+				cancel()
+				return
+			}
+		}
+	}()
+	if err := spot.RunUserDataStream(ctx, c, binance.UserDataHandlers{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunUserDataStream = %v; want context.Canceled (no reconnect after keepalive failure)", err)
+	}
+
+	if got := <-srv.keepAlives; got != "key-1" {
+		t.Fatalf("keepalive listenKey = %q", got)
+	}
+	var apiErr *binance.APIError
+	if err := <-keepAliveErrs; !errors.As(err, &apiErr) || apiErr.Code != -1125 {
+		t.Fatalf("keepalive error = %v", err)
+	}
+	if srv.creates.Load() < 2 {
+		t.Fatalf("listenKey creates = %d; want a new key after keepalive failure", srv.creates.Load())
+	}
+}
+
+func TestSpotServiceCreateOrderSignature(t *testing.T) {
+	const secret = "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/time", serverTime)
+	mux.HandleFunc("/api/v3/order", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-MBX-APIKEY") != "key" {
+			t.Errorf("%s with api key %q", r.Method, r.Header.Get("X-MBX-APIKEY"))
+		}
+		// signature 须是最后一个参数，且是对其前面原样查询串的签名
+		raw := r.URL.RawQuery
+		i := strings.LastIndex(raw, "&signature=")
+		if i < 0 {
+			t.Errorf("query without signature: %s", raw)
+			return
+		}
+		if want := binance.NewHMACSigner(secret).Sign(raw[:i]); raw[i+len("&signature="):] != want {
+			t.Errorf("signature = %s; want %s", raw[i+len("&signature="):], want)
+		}
+		q, _ := url.ParseQuery(raw[:i])
+		want := url.Values{
+			"symbol": {"BTCUSDT"}, "side": {"BUY"}, "type": {"LIMIT"}, "timeInForce": {"GTC"},
+			"quantity": {"0.01"}, "price": {"30000.5"}, "newClientOrderId": {"my-order-1"},
+			"newOrderRespType": {"FULL"}, "recvWindow": {"5000"},
+		}
+		for k, v := range want {
+			if q.Get(k) != v[0] {
+				t.Errorf("%s = %q; want %q", k, q.Get(k), v[0])
+			}
+		}
+		// serverTime 固定返回 1700000000000，timestamp 应按时差校正到服务器时间附近
+		if ts, _ := strconv.ParseInt(q.Get("timestamp"), 10, 64); time.UnixMilli(ts).Sub(time.UnixMilli(1700000000000)).Abs() > time.Minute {
+			t.Errorf("timestamp = %s", q.Get("timestamp"))
+		}
+		_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"my-order-1","transactTime":1507725176595,` +
+			`"price":"30000.50","origQty":"0.01","executedQty":"0.01","cummulativeQuoteQty":"300.005","status":"FILLED",` +
+			`"timeInForce":"GTC","type":"LIMIT","side":"BUY",` +
+			`"fills":[{"price":"30000.50","qty":"0.01","commission":"0.00001","commissionAsset":"BTC","tradeId":56}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	spot := binance.NewSpotService("key", binance.NewHMACSigner(secret))
+	spot.Pool = binance.NewEndpointPool(srv.Client(), srv.URL)
+	order, err := spot.CreateOrder(context.Background(), binance.NewOrder{
+		Symbol: "BTCUSDT", Side: binance.SideBuy, Type: binance.OrderTypeLimit, TimeInForce: binance.TimeInForceGTC,
+		Quantity: decimal.RequireFromString("0.01"), Price: decimal.RequireFromString("30000.5"), ClientOrderID: "my-order-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderID != 28 || order.Status != binance.OrderStatusFilled || len(order.Fills) != 1 || order.Fills[0].TradeID != 56 {
+		t.Fatalf("order = %+v", order)
+	}
+}
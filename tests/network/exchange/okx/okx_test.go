@@ -0,0 +1,124 @@
+package okx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/network/exchange/okx"
+)
+
+const bookJSON = `{"code":0,"msg":"","data":{
+"sell":[
+ {"id":"s1","side":"sell","baseCurrency":"usdt","quoteCurrency":"cny","price":"7.25","availableAmount":"100","quoteMinAmountPerOrder":"100","quoteMaxAmountPerOrder":"700","nickName":"A","completedOrderQuantity":900,"completedRate":"0.99","paymentMethods":["bank"]},
+ {"id":"s2","side":"sell","baseCurrency":"usdt","quoteCurrency":"cny","price":"7.21","availableAmount":"5000","quoteMinAmountPerOrder":"1000","quoteMaxAmountPerOrder":"50000","nickName":"B","merchantId":"m2","completedOrderQuantity":12000,"completedRate":"0.985","isInstitution":1},
+ {"id":"s3","side":"sell","baseCurrency":"usdt","quoteCurrency":"cny","price":"7.22","availableAmount":"800","quoteMinAmountPerOrder":"100","quoteMaxAmountPerOrder":"10000","nickName":"C"}
+],
+"buy":[
+ {"id":"b1","side":"buy","baseCurrency":"usdt","quoteCurrency":"cny","price":"7.05","availableAmount":"3000","quoteMinAmountPerOrder":"100","quoteMaxAmountPerOrder":"20000","nickName":"D"},
+ {"id":"b2","side":"buy","baseCurrency":"usdt","quoteCurrency":"cny","price":"7.09","availableAmount":"3000","quoteMinAmountPerOrder":"100","quoteMaxAmountPerOrder":"20000","nickName":"E"}
+]}}`
+
+func newTestOKX(t *testing.T, h http.HandlerFunc) *okx.OKX {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return &okx.OKX{Client: srv.Client(), BaseURL: srv.URL}
+}
+
+func TestGetBook(t *testing.T) {
+	var gotQuery string
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(bookJSON))
+	})
+	ctx := context.Background()
+
+	sells, err := o.GetBook(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY"})
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	if len(sells) != 3 || sells[0].ID != "s2" || sells[2].ID != "s1" {
+		t.Fatalf("sell order = %v", ids(sells))
+	}
+	b := sells[0]
+	if b.Price != "7.21" || !b.PriceValue.Equal(decimal.RequireFromString("7.21")) {
+		t.Fatalf("price = %q / %s", b.Price, b.PriceValue)
+	}
+	if b.ShopName != "B" || b.MerchantID != "m2" || b.CompletedOrders != 12000 || !b.Institution ||
+		!b.CompletionRate.Equal(decimal.RequireFromString("0.985")) || !b.MaxAmount.Equal(decimal.NewFromInt(50000)) {
+		t.Fatalf("exchange = %+v", b)
+	}
+
+	buys, err := o.GetBook(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY", Side: okx.SideBuy})
+	if err != nil || len(buys) != 2 || buys[0].ID != "b2" {
+		t.Fatalf("buys = %v, err = %v", ids(buys), err)
+	}
+	if want := "side=buy"; !strings.Contains(gotQuery, want) {
+		t.Fatalf("query %q lacks %q", gotQuery, want)
+	}
+
+	page, err := o.GetBook(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY", Limit: 1, Offset: 1})
+	if err != nil || len(page) != 1 || page[0].ID != "s3" {
+		t.Fatalf("page = %v, err = %v", ids(page), err)
+	}
+	if !strings.Contains(gotQuery, "limit=2") {
+		t.Fatalf("query = %q", gotQuery)
+	}
+}
+
+func TestBestPriceForAmount(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(bookJSON))
+	})
+	ctx := context.Background()
+
+	// 500 CNY 低于 s2 的单笔下限，s3 最优
+	best, err := o.BestPrice(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY", Amount: decimal.NewFromInt(500)})
+	if err != nil || best.ID != "s3" {
+		t.Fatalf("best = %+v, err = %v", best, err)
+	}
+	// 5000 CNY：s1 超出单笔上限，s2 满足限额且价格最低
+	best, err = o.BestPrice(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY", Amount: decimal.NewFromInt(5000)})
+	if err != nil || best.ID != "s2" {
+		t.Fatalf("best = %+v, err = %v", best, err)
+	}
+	if _, err := o.BestPrice(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY", Amount: decimal.NewFromInt(1_000_000)}); !errors.Is(err, okx.ErrNoAds) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestGetBookErrors(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"code":50011,"msg":"Too Many Requests","data":{}}`))
+	})
+	var apiErr *okx.APIError
+	if _, err := o.GetBook(context.Background(), okx.BookQuery{Base: "USDT", Quote: "CNY"}); !errors.As(err, &apiErr) || apiErr.Code != 50011 {
+		t.Fatalf("err = %v", err)
+	}
+
+	o = newTestOKX(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	if _, err := o.GetBook(context.Background(), okx.BookQuery{Base: "USDT", Quote: "CNY"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("err = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := o.GetBook(ctx, okx.BookQuery{Base: "USDT", Quote: "CNY"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func ids(es []*okx.Exchange) []string {
+	out := make([]string, len(es))
+	for i, e := range es {
+		out[i] = e.ID
+	}
+	return out
+}
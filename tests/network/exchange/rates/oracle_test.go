@@ -0,0 +1,205 @@
+package rates_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/bizvip/go-utils/network/exchange/binance"
+	"github.com/bizvip/go-utils/network/exchange/rates"
+)
+
+// fakeSource 返回固定报价或错误，并记录调用次数。
+type fakeSource struct {
+	name   string
+	price  string
+	volume string
+	age    time.Duration
+	err    error
+	calls  atomic.Int32
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Price(_ context.Context, base, quote string) (rates.Quote, error) {
+	f.calls.Add(1)
+	if f.err != nil {
+		return rates.Quote{}, f.err
+	}
+	q := rates.Quote{Source: f.name, Base: base, Quote: quote, Price: decimal.RequireFromString(f.price), Time: time.Now().Add(-f.age)}
+	if f.volume != "" {
+		q.Volume = decimal.RequireFromString(f.volume)
+	}
+	return q, nil
+}
+
+func TestAggregate(t *testing.T) {
+	quotes := []rates.Quote{
+		{Price: decimal.RequireFromString("7.10"), Volume: decimal.NewFromInt(1)},
+		{Price: decimal.RequireFromString("7.20"), Volume: decimal.NewFromInt(3)},
+		{Price: decimal.RequireFromString("7.30")},
+		{Price: decimal.RequireFromString("7.00")},
+	}
+	if got, _ := rates.Aggregate(quotes, rates.Median); !got.Equal(decimal.RequireFromString("7.15")) {
+		t.Fatalf("median = %s", got)
+	}
+	if got, _ := rates.Aggregate(quotes, rates.VWAP); !got.Equal(decimal.RequireFromString("7.175")) {
+		t.Fatalf("vwap = %s", got)
+	}
+	if _, err := rates.Aggregate(nil, rates.Median); !errors.Is(err, rates.ErrNoPrice) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestOracleRejectsOutliers(t *testing.T) {
+	o := rates.NewOracle(
+		&fakeSource{name: "a", price: "7.20"},
+		&fakeSource{name: "b", price: "7.22"},
+		&fakeSource{name: "c", price: "9.99"},
+	)
+	r, err := o.Rate(context.Background(), "usdt", "cny")
+	if err != nil {
+		t.Fatalf("Rate: %v", err)
+	}
+	if !r.Price.Equal(decimal.RequireFromString("7.21")) || len(r.Quotes) != 2 || len(r.Rejected) != 1 || r.Rejected[0].Source != "c" {
+		t.Fatalf("rate = %+v", r)
+	}
+	if r.Base != "USDT" || r.Quote != "CNY" {
+		t.Fatalf("pair = %s/%s", r.Base, r.Quote)
+	}
+}
+
+func TestRejectOutliersZeroMedian(t *testing.T) {
+	// 多数报价为零时中位数为零，不能以其为分母
+	quotes := []rates.Quote{
+		{Source: "a", Price: decimal.Zero},
+		{Source: "b", Price: decimal.Zero},
+		{Source: "c", Price: decimal.RequireFromString("7.20")},
+	}
+	kept, rejected := rates.RejectOutliers(quotes, decimal.RequireFromString("0.02"))
+	if len(kept) != 1 || kept[0].Source != "c" || len(rejected) != 2 {
+		t.Fatalf("kept = %+v, rejected = %+v", kept, rejected)
+	}
+	if kept, rejected := rates.RejectOutliers(quotes[:2], decimal.RequireFromString("0.02")); len(kept) != 0 || len(rejected) != 2 {
+		t.Fatalf("all zero: kept = %+v, rejected = %+v", kept, rejected)
+	}
+}
+
+func TestOracleWithoutSources(t *testing.T) {
+	_, err := rates.NewOracle().Rate(context.Background(), "usdt", "cny")
+	if !errors.Is(err, rates.ErrNoSources) || strings.Contains(err.Error(), "%!") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestOracleNeverReturnsZero(t *testing.T) {
+	o := rates.NewOracle(
+		&fakeSource{name: "zero", price: "0"},
+		&fakeSource{name: "stale", price: "7.2", age: time.Hour},
+		&fakeSource{name: "down", err: errors.New("connection refused")},
+	)
+	_, err := o.Rate(context.Background(), "USDT", "CNY")
+	if !errors.Is(err, rates.ErrNoPrice) || !errors.Is(err, rates.ErrInvalidPrice) || !errors.Is(err, rates.ErrStale) {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := o.Convert(context.Background(), decimal.NewFromInt(100), "USDT", "CNY"); err == nil {
+		t.Fatal("Convert should fail")
+	}
+}
+
+func TestOracleFallbackAndCache(t *testing.T) {
+	primary := &fakeSource{name: "primary", err: rates.ErrUnsupportedPair}
+	backup1 := &fakeSource{name: "backup1", err: errors.New("timeout")}
+	backup2 := &fakeSource{name: "backup2", price: "7.25"}
+	o := rates.NewOracle(primary)
+	o.Fallbacks = []rates.PriceSource{backup1, backup2}
+
+	for i := 0; i < 3; i++ {
+		r, err := o.Rate(context.Background(), "USDT", "CNY")
+		if err != nil {
+			t.Fatalf("Rate: %v", err)
+		}
+		if r.Quotes[0].Source != "backup2" {
+			t.Fatalf("source = %s", r.Quotes[0].Source)
+		}
+	}
+	// TTL 内只询价一次
+	if primary.calls.Load() != 1 || backup2.calls.Load() != 1 {
+		t.Fatalf("calls = %d, %d", primary.calls.Load(), backup2.calls.Load())
+	}
+}
+
+func TestOracleServesCacheWithinMaxAge(t *testing.T) {
+	src := &fakeSource{name: "a", price: "7.2"}
+	o := rates.NewOracle(src)
+	o.TTL = time.Nanosecond
+	if _, err := o.Rate(context.Background(), "USDT", "CNY"); err != nil {
+		t.Fatal(err)
+	}
+	src.err = errors.New("down")
+	r, err := o.Rate(context.Background(), "USDT", "CNY")
+	if err != nil || !r.Price.Equal(decimal.RequireFromString("7.2")) {
+		t.Fatalf("rate = %+v, err = %v", r, err)
+	}
+
+	o.MaxAge = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, err := o.Rate(context.Background(), "USDT", "CNY"); err == nil {
+		t.Fatal("expected error once cache exceeds MaxAge")
+	}
+}
+
+func TestOracleConvertInverse(t *testing.T) {
+	src := &fakeSource{name: "a", price: "8"}
+	inverseOnly := rates.NewOracle(&pairSource{fakeSource: src, base: "USDT", quote: "CNY"})
+	got, err := inverseOnly.Convert(context.Background(), decimal.NewFromInt(100), "CNY", "USDT")
+	if err != nil || !got.Equal(decimal.RequireFromString("12.5")) {
+		t.Fatalf("Convert = %s, %v", got, err)
+	}
+}
+
+// pairSource 只支持一个交易对。
+type pairSource struct {
+	*fakeSource
+	base, quote string
+}
+
+func (p *pairSource) Price(ctx context.Context, base, quote string) (rates.Quote, error) {
+	if base != p.base || quote != p.quote {
+		return rates.Quote{}, rates.ErrUnsupportedPair
+	}
+	return p.fakeSource.Price(ctx, base, quote)
+}
+
+func TestBinanceSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbols") != `["BTCUSDT"]` {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","lastPrice":"65000.10","volume":"1234.5","closeTime":` + now + `}]`))
+	}))
+	defer srv.Close()
+
+	src := rates.NewBinanceSource(&binance.MarketService{Pool: binance.NewEndpointPool(srv.Client(), srv.URL)})
+	q, err := src.Price(context.Background(), "btc", "usdt")
+	if err != nil {
+		t.Fatalf("Price: %v", err)
+	}
+	if !q.Price.Equal(decimal.RequireFromString("65000.10")) || !q.Volume.Equal(decimal.RequireFromString("1234.5")) || time.Since(q.Time) > time.Minute {
+		t.Fatalf("quote = %+v", q)
+	}
+	if _, err := src.Price(context.Background(), "USDT", "CNY"); !errors.Is(err, rates.ErrUnsupportedPair) {
+		t.Fatalf("err = %v", err)
+	}
+}
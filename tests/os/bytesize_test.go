@@ -0,0 +1,136 @@
+package os_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bizvip/go-utils/configer"
+	osx "github.com/bizvip/go-utils/os"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want osx.ByteSize
+	}{
+		{"1024", 1024},
+		{"0", 0},
+		{"512B", 512},
+		{"10k", 10 * osx.KiB},
+		{"10 KiB", 10 * osx.KiB},
+		{"200MB", 200 * osx.MBSI},
+		{"200mb", 200 * osx.MBSI},
+		{"200Mi", 200 * osx.MiB},
+		{"1.5GiB", 1536 * osx.MiB},
+		{"1.5 G", 1536 * osx.MiB},
+		{"2TB", 2 * osx.TBSI},
+		{"-1MiB", -osx.MiB},
+		{"1.50 MiB", 1536 * osx.KiB},
+		{"1.1KiB", 1126},
+		{" 3 bytes ", 3},
+	}
+	for _, c := range cases {
+		got, err := osx.ParseByteSize(c.in)
+		if err != nil || got != c.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", c.in, got, err, c.want)
+		}
+	}
+	for _, in := range []string{"", "MB", "-", "10XB", "1.2.3k", "9EiB", "10000000PB"} {
+		if _, err := osx.ParseByteSize(in); !errors.Is(err, osx.ErrInvalidByteSize) {
+			t.Errorf("ParseByteSize(%q) error = %v", in, err)
+		}
+	}
+}
+
+func TestByteSizeMarshalText(t *testing.T) {
+	cases := map[osx.ByteSize]string{
+		0:                     "0B",
+		1000:                  "1000B",
+		osx.KiB:               "1KiB",
+		1536 * osx.MiB:        "1.5GiB",
+		osx.GiB + 256*osx.MiB: "1.25GiB",
+		100 * osx.MBSI:        "97656.25KiB",
+		-5:                    "-5B",
+		-1536 * osx.KiB:       "-1.5MiB",
+	}
+	for in, want := range cases {
+		b, err := in.MarshalText()
+		if err != nil || string(b) != want {
+			t.Errorf("MarshalText(%d) = %s, want %s", in, b, want)
+		}
+		var back osx.ByteSize
+		if err := back.UnmarshalText(b); err != nil || back != in {
+			t.Errorf("round trip %d = %d, %v", in, back, err)
+		}
+	}
+}
+
+func TestByteSizeStringRoundTrip(t *testing.T) {
+	for _, in := range []osx.ByteSize{0, 512, -5, osx.KiB, 1536 * osx.KiB, 200 * osx.MiB, 3 * osx.GiB, 2 * osx.TiB} {
+		got, err := osx.ParseByteSize(in.String())
+		if err != nil || got != in {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", in.String(), got, err, in)
+		}
+	}
+	// 两位小数会丢失精度，解析回的值与原值相差不超过所用单位的 0.005
+	in := osx.ByteSize(200*osx.MiB + 12345)
+	got, err := osx.ParseByteSize(in.String())
+	if diff := got - in; err != nil || diff > osx.MiB/200 || diff < -osx.MiB/200 {
+		t.Errorf("ParseByteSize(%q) = %d, %v, want about %d", in.String(), got, err, in)
+	}
+}
+
+type uploadConfig struct {
+	MaxUpload osx.ByteSize `json:"max_upload" yaml:"max_upload"`
+	MaxLog    osx.ByteSize `json:"max_log" yaml:"max_log"`
+}
+
+func TestByteSizeJSONAndYAML(t *testing.T) {
+	var cfg uploadConfig
+	if err := json.Unmarshal([]byte(`{"max_upload":"20MiB","max_log":1048576}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxUpload != 20*osx.MiB || cfg.MaxLog != osx.MiB {
+		t.Fatalf("json = %+v", cfg)
+	}
+	out, _ := json.Marshal(cfg)
+	if string(out) != `{"max_upload":"20MiB","max_log":"1MiB"}` {
+		t.Fatalf("json marshal = %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"max_upload":"lots"}`), &cfg); !errors.Is(err, osx.ErrInvalidByteSize) {
+		t.Fatalf("json invalid: %v", err)
+	}
+
+	cfg = uploadConfig{}
+	if err := yaml.Unmarshal([]byte("max_upload: 1.5GiB\nmax_log: 4096\n"), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxUpload != 1536*osx.MiB || cfg.MaxLog != 4096 {
+		t.Fatalf("yaml = %+v", cfg)
+	}
+	out, _ = yaml.Marshal(cfg)
+	if string(out) != "max_upload: 1.5GiB\nmax_log: 4KiB\n" {
+		t.Fatalf("yaml marshal = %q", out)
+	}
+}
+
+func TestByteSizeWithConfiger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("max_upload: 200MB\nmax_log: 10k\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := configer.Load[uploadConfig](path,
+		configer.WithDecoder[uploadConfig](configer.DecoderFunc(yaml.Unmarshal)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxUpload != 200*osx.MBSI || cfg.MaxLog != 10*osx.KiB {
+		t.Fatalf("config = %+v", cfg)
+	}
+}